@@ -0,0 +1,145 @@
+package bdo
+
+import (
+	"sort"
+	"strings"
+)
+
+// Assignment is an owned production node and the town of the worker
+// assigned to it, Hops connections away.
+type Assignment struct {
+	Node string `json:"node"`
+	Town string `json:"town"`
+	Hops int    `json:"hops"`
+}
+
+// assignPriorityBonus outweighs any difference in travel, so nodes producing
+// priority items are always assigned before others.
+const assignPriorityBonus = 10000
+
+// Assign returns assignments of workers to owned production nodes, where
+// workers is the number of workers available in each town. Workers only
+// travel through owned nodes, and as many nodes as possible are assigned,
+// preferring those that produce any of the priority items and then the least
+// total travel. The result is sorted by node name.
+func (g *Graph) Assign(workers map[string]int, priority []string) []*Assignment {
+	prioritized := map[string]struct{}{}
+	for _, p := range priority {
+		prioritized[strings.ToLower(p)] = struct{}{}
+	}
+	var towns []string
+	for t, count := range workers {
+		if count > 0 {
+			towns = append(towns, t)
+		}
+	}
+	sort.Strings(towns)
+	var targets []string
+	for _, n := range g.Nodes() {
+		if n.Owned && len(n.Produces) > 0 {
+			targets = append(targets, n.Name)
+		}
+	}
+	// Nodes of the flow network: 0 is the source, 1 the sink, then the
+	// towns, then the production nodes.
+	f := &flow{}
+	f.init(2 + len(towns) + len(targets))
+	type townEdge struct {
+		town, target, edge, hops int
+	}
+	var townEdges []townEdge
+	for i, t := range towns {
+		f.addEdge(0, 2+i, workers[t], 0)
+		hops := g.hops(t, true)
+		for j, n := range targets {
+			h, ok := hops[n]
+			if !ok {
+				continue
+			}
+			cost := h
+			for _, p := range g.nodes[n].Produces {
+				if _, ok := prioritized[strings.ToLower(p)]; ok {
+					cost -= assignPriorityBonus
+					break
+				}
+			}
+			townEdges = append(townEdges, townEdge{i, j, len(f.edges[2+i]), h})
+			f.addEdge(2+i, 2+len(towns)+j, 1, cost)
+		}
+	}
+	for j := range targets {
+		f.addEdge(2+len(towns)+j, 1, 1, 0)
+	}
+	f.run(0, 1)
+	var assignments []*Assignment
+	for _, te := range townEdges {
+		if f.edges[2+te.town][te.edge].cap == 0 {
+			assignments = append(assignments, &Assignment{Node: targets[te.target], Town: towns[te.town], Hops: te.hops})
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].Node < assignments[j].Node })
+	return assignments
+}
+
+// flow is a minimum cost maximum flow network, solved with successive
+// shortest paths found by Bellman-Ford so that negative costs are allowed.
+type flow struct {
+	edges [][]flowEdge
+}
+
+type flowEdge struct {
+	to, cap, cost, rev int
+}
+
+func (f *flow) init(n int) {
+	f.edges = make([][]flowEdge, n)
+}
+
+func (f *flow) addEdge(from, to, cap, cost int) {
+	f.edges[from] = append(f.edges[from], flowEdge{to, cap, cost, len(f.edges[to])})
+	f.edges[to] = append(f.edges[to], flowEdge{from, 0, -cost, len(f.edges[from]) - 1})
+}
+
+func (f *flow) run(source, sink int) {
+	n := len(f.edges)
+	infinity := int(^uint(0) >> 1)
+	for {
+		dist := make([]int, n)
+		prevNode := make([]int, n)
+		prevEdge := make([]int, n)
+		for i := range dist {
+			dist[i] = infinity
+		}
+		dist[source] = 0
+		for changed := true; changed; {
+			changed = false
+			for v := 0; v < n; v++ {
+				if dist[v] == infinity {
+					continue
+				}
+				for i, e := range f.edges[v] {
+					if e.cap > 0 && dist[v]+e.cost < dist[e.to] {
+						dist[e.to] = dist[v] + e.cost
+						prevNode[e.to] = v
+						prevEdge[e.to] = i
+						changed = true
+					}
+				}
+			}
+		}
+		if dist[sink] == infinity {
+			return
+		}
+		push := infinity
+		for v := sink; v != source; v = prevNode[v] {
+			if c := f.edges[prevNode[v]][prevEdge[v]].cap; c < push {
+				push = c
+			}
+		}
+		for v := sink; v != source; v = prevNode[v] {
+			e := &f.edges[prevNode[v]][prevEdge[v]]
+			e.cap -= push
+			f.edges[v][e.rev].cap += push
+		}
+	}
+}
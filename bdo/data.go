@@ -0,0 +1,152 @@
+package bdo
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+//go:embed nodes.json
+var defaultNodesData []byte
+
+// nodesData is the format of the node data file. It is a JSON object with a
+// "nodes" list, each entry of which is read in order as if it were a call to
+// AddNode, followed by a call to AddProductionNode for each of its
+// "production" entries and a call to AddConnection for each of its
+// "connections"; and an optional "tradeBonus", the TradeBonus. For example:
+//
+//	{
+//		"tradeBonus": {"perDistance": 0.25, "max": 150},
+//		"nodes": [
+//			{
+//				"name": "Ehwaz Hill",
+//				"cp": 1,
+//				"kind": "production",
+//				"region": "Balenos",
+//				"territory": "Velia",
+//				"position": {"x": -112, "y": 14},
+//				"production": [
+//					{
+//						"name": "A",
+//						"cp": 1,
+//						"closestWorker": "Velia",
+//						"produces": ["Fortune Teller Mushroom"],
+//						"workload": 100,
+//						"distance": 1200
+//					}
+//				],
+//				"connections": ["Forest of Plunder", "Finto Farm"],
+//				"missingConnections": ["Some Node Not Yet Entered"]
+//			}
+//		]
+//	}
+//
+// The "kind" is one of the Kinds and defaults as AddNode sets it; "region",
+// "territory", and "position" on the world map, used for trade distances,
+// are optional. The built in nodes.json has no positions or "tradeBonus" yet. Production nodes are named "<parent>: <name>" and are
+// connected to their parent automatically, and they take their parent's
+// region and territory. Their "workload" and "distance", from the
+// closest worker town, are optional and are used to estimate production; the
+// built in nodes.json does not have them yet. The
+// "missingConnections" entries are known connections to nodes that have not
+// been entered yet; they are kept in the data so they are not forgotten but
+// are otherwise ignored. Every connection must be listed from both of its
+// ends.
+type nodesData struct {
+	TradeBonus *TradeBonus `json:"tradeBonus,omitempty"`
+	Nodes      []nodeData  `json:"nodes"`
+}
+
+type nodeData struct {
+	Name               string           `json:"name"`
+	CP                 int              `json:"cp"`
+	Kind               string           `json:"kind,omitempty"`
+	Region             string           `json:"region,omitempty"`
+	Territory          string           `json:"territory,omitempty"`
+	Position           *Position        `json:"position,omitempty"`
+	Production         []productionData `json:"production,omitempty"`
+	Connections        []string         `json:"connections,omitempty"`
+	MissingConnections []string         `json:"missingConnections,omitempty"`
+}
+
+type productionData struct {
+	Name          string   `json:"name"`
+	CP            int      `json:"cp"`
+	ClosestWorker string   `json:"closestWorker,omitempty"`
+	Produces      []string `json:"produces"`
+	Workload      int      `json:"workload,omitempty"`
+	Distance      float64  `json:"distance,omitempty"`
+}
+
+// DefaultGraph returns the graph for the node data shipped with this package.
+func DefaultGraph() (*Graph, error) {
+	g, err := Load(bytes.NewReader(defaultNodesData))
+	if err != nil {
+		return nil, fmt.Errorf("default node data: %s", err)
+	}
+	return g, nil
+}
+
+// LoadFile returns the graph for the node data in the named file; see Load.
+func LoadFile(filename string) (*Graph, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	g, err := Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return g, nil
+}
+
+// Load returns the graph for the node data read from r. The data is the same
+// format as the nodes.json file shipped with this package, which is a good
+// starting point for a corrected copy. An error is returned for the first
+// problem Lint finds that would leave the graph inconsistent.
+func Load(r io.Reader) (*Graph, error) {
+	var data nodesData
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	for _, p := range data.lint().Problems {
+		switch p.Kind {
+		case ProblemDangling, ProblemOneWay, ProblemUnknownKind:
+			return nil, errors.New(p.Message)
+		}
+	}
+	g := NewGraph()
+	g.tradeBonus = data.TradeBonus
+	for _, nd := range data.Nodes {
+		n := g.AddNode(nd.Name, nd.CP)
+		if nd.Kind != "" {
+			n.Kind = nd.Kind
+		}
+		n.Region = nd.Region
+		n.Territory = nd.Territory
+		n.Position = nd.Position
+		for _, pd := range nd.Production {
+			n := g.AddProductionNode(nd.Name, pd.Name, pd.CP, pd.ClosestWorker, pd.Produces...)
+			n.Workload = pd.Workload
+			n.Distance = pd.Distance
+		}
+		for _, c := range nd.Connections {
+			g.AddConnection(nd.Name, c)
+		}
+	}
+	return g, nil
+}
+
+func validKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
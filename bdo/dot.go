@@ -0,0 +1,110 @@
+package bdo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DOTOptions control what WriteDOT includes.
+type DOTOptions struct {
+	// Include limits the output to just these nodes; nil means every node.
+	Include map[string]struct{}
+	// Highlight is a path of nodes, such as one from BestPaths, to make
+	// stand out along with the connections between them.
+	Highlight []string
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Towns are bold
+// boxes, production nodes are rounded boxes, owned nodes are filled, and
+// nodes with an assigned worker are outlined in green and labeled with the
+// worker's town.
+func (g *Graph) WriteDOT(w io.Writer, opts *DOTOptions) error {
+	if opts == nil {
+		opts = &DOTOptions{}
+	}
+	included := func(name string) bool {
+		if opts.Include == nil {
+			return true
+		}
+		_, ok := opts.Include[name]
+		return ok
+	}
+	highlight := map[string]struct{}{}
+	highlightEdge := map[[2]string]struct{}{}
+	for i, n := range opts.Highlight {
+		highlight[n] = struct{}{}
+		if i > 0 {
+			highlightEdge[[2]string{opts.Highlight[i-1], n}] = struct{}{}
+			highlightEdge[[2]string{n, opts.Highlight[i-1]}] = struct{}{}
+		}
+	}
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "graph bdo {")
+	fmt.Fprintln(b, "    node [shape=ellipse, fontsize=10];")
+	for _, n := range g.Nodes() {
+		if !included(n.Name) {
+			continue
+		}
+		label := fmt.Sprintf("%s\n%d CP", n.Name, n.ContributionPoints)
+		var attrs []string
+		var styles []string
+		switch {
+		case n.Kind == KindTown:
+			attrs = append(attrs, "shape=box")
+			styles = append(styles, "bold")
+		case len(n.Produces) > 0:
+			attrs = append(attrs, "shape=box")
+			styles = append(styles, "rounded")
+			label += "\n" + strings.Join(n.Produces, ", ")
+		}
+		if n.Owned {
+			styles = append(styles, "filled")
+			attrs = append(attrs, "fillcolor=lightblue")
+		}
+		if n.AssignedWorker != "" {
+			label += "\nworker from " + n.AssignedWorker
+			attrs = append(attrs, "color=darkgreen", "penwidth=2")
+		}
+		if _, ok := highlight[n.Name]; ok {
+			attrs = append(attrs, "color=red", "penwidth=3")
+		}
+		if len(styles) > 0 {
+			attrs = append(attrs, fmt.Sprintf("style=%q", strings.Join(styles, ",")))
+		}
+		attrs = append(attrs, fmt.Sprintf("label=%q", label))
+		fmt.Fprintf(b, "    %q [%s];\n", n.Name, strings.Join(attrs, ", "))
+	}
+	for _, n := range g.Nodes() {
+		if !included(n.Name) {
+			continue
+		}
+		for _, c := range g.Connections(n.Name) {
+			// Each connection is listed from both ends, so only write it
+			// from one of them unless the other end is missing it.
+			if !included(c) || (c < n.Name && g.Connected(c, n.Name)) {
+				continue
+			}
+			if _, ok := highlightEdge[[2]string{n.Name, c}]; ok {
+				fmt.Fprintf(b, "    %q -- %q [color=red, penwidth=3];\n", n.Name, c)
+			} else {
+				fmt.Fprintf(b, "    %q -- %q;\n", n.Name, c)
+			}
+		}
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// Around returns the names of the nodes within hops connections of the named
+// node, including the node itself.
+func (g *Graph) Around(name string, hops int) map[string]struct{} {
+	found := map[string]struct{}{}
+	for n, h := range g.hops(name, false) {
+		if h <= hops {
+			found[n] = struct{}{}
+		}
+	}
+	return found
+}
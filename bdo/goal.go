@@ -0,0 +1,157 @@
+package bdo
+
+import "sort"
+
+// Shortfall is how much of an item a goal needs each day, how much the
+// worked nodes produce, and how much the planned purchases would add.
+type Shortfall struct {
+	Item    string  `json:"item"`
+	Need    float64 `json:"need"`
+	Have    float64 `json:"have"`
+	Planned float64 `json:"planned"`
+	// Short is what is still needed after the planned purchases; it is only
+	// above zero if no more estimated nodes producing the item can be
+	// connected.
+	Short float64 `json:"short"`
+	// Unestimated are the nodes producing the item whose output could not be
+	// estimated, so they are left out of Have and Planned: the owned ones
+	// with workers assigned and, if the item is still short, the unowned ones
+	// that might make up the rest.
+	Unestimated []string `json:"unestimated,omitempty"`
+}
+
+// PerDay returns the output per day of the named production node worked by a
+// worker from town, running all day, and true; or 0 and false if Estimate
+// cannot estimate it.
+func (g *Graph) PerDay(name string, town string) (float64, bool) {
+	if e := g.Estimate(name, town); e != nil {
+		return e.ItemsPerHour * 24, true
+	}
+	return 0, false
+}
+
+// perItemPerDay returns PerDay split evenly across the items the node
+// produces, as a worker only works one of them at a time.
+func (g *Graph) perItemPerDay(name string, town string) (float64, bool) {
+	perDay, estimated := g.PerDay(name, town)
+	if n := len(g.nodes[name].Produces); n > 1 {
+		perDay /= float64(n)
+	}
+	return perDay, estimated
+}
+
+// Goal compares the items needed each day with what the owned nodes with
+// workers assigned produce, and chooses unowned production nodes to buy,
+// along with the nodes needed to connect them, until each item's need is met
+// or no more nodes producing it can be connected. A node's output is split
+// evenly across the items it produces, and new nodes are counted as worked
+// from their closest worker town. Nodes whose output cannot be estimated are
+// not counted or bought, only listed in each Shortfall's Unestimated. Each
+// Purchase's Value is how much it adds toward the needs each day.
+//
+// Each step buys the cheapest node that helps, preferring the one adding the
+// most, so the purchases are in order of cost as the network grows.
+func (g *Graph) Goal(need map[string]float64) ([]*Shortfall, []*Purchase) {
+	g = g.Clone()
+	var shortfalls []*Shortfall
+	byItem := map[string]*Shortfall{}
+	for item, count := range need {
+		s := &Shortfall{Item: item, Need: count}
+		shortfalls = append(shortfalls, s)
+		byItem[item] = s
+	}
+	sort.Slice(shortfalls, func(i, j int) bool { return shortfalls[i].Item < shortfalls[j].Item })
+	for _, n := range g.Nodes() {
+		if !n.Owned || n.AssignedWorker == "" {
+			continue
+		}
+		perDay, estimated := g.perItemPerDay(n.Name, n.AssignedWorker)
+		for _, p := range n.Produces {
+			if s := byItem[p]; s != nil {
+				if estimated {
+					s.Have += perDay
+				} else {
+					s.Unestimated = append(s.Unestimated, n.Name)
+				}
+			}
+		}
+	}
+	gap := func(s *Shortfall) float64 {
+		if v := s.Need - s.Have - s.Planned; v > 0 {
+			return v
+		}
+		return 0
+	}
+	var plan []*Purchase
+	for {
+		var best *Purchase
+		for _, n := range g.Nodes() {
+			if n.Owned || len(n.Produces) == 0 {
+				continue
+			}
+			perDay, estimated := g.perItemPerDay(n.Name, n.ClosestWorker)
+			if !estimated {
+				continue
+			}
+			var items []string
+			value := 0.0
+			for _, p := range n.Produces {
+				if s := byItem[p]; s != nil && gap(s) > 0 {
+					items = append(items, p)
+					if perDay < gap(s) {
+						value += perDay
+					} else {
+						value += gap(s)
+					}
+				}
+			}
+			if value == 0 {
+				continue
+			}
+			cost, pths := g.BestPaths(n.Name, "")
+			if len(pths) == 0 {
+				continue
+			}
+			if best != nil && (cost > best.Cost || (cost == best.Cost && value <= best.Value)) {
+				continue
+			}
+			best = &Purchase{Node: n.Name, Cost: cost, Value: value, Items: items}
+			pth := pths[0]
+			for i := len(pth) - 1; i >= 0; i-- {
+				if !g.nodes[pth[i]].Owned {
+					best.Buy = append(best.Buy, pth[i])
+				}
+			}
+		}
+		if best == nil {
+			break
+		}
+		for _, n := range best.Buy {
+			g.nodes[n].Owned = true
+		}
+		n := g.nodes[best.Node]
+		perDay, _ := g.perItemPerDay(n.Name, n.ClosestWorker)
+		for _, p := range best.Items {
+			byItem[p].Planned += perDay
+		}
+		sort.Strings(best.Items)
+		plan = append(plan, best)
+	}
+	for _, s := range shortfalls {
+		s.Short = gap(s)
+	}
+	for _, n := range g.Nodes() {
+		if n.Owned || len(n.Produces) == 0 {
+			continue
+		}
+		if _, estimated := g.PerDay(n.Name, n.ClosestWorker); estimated {
+			continue
+		}
+		for _, p := range n.Produces {
+			if s := byItem[p]; s != nil && s.Short > 0 {
+				s.Unestimated = append(s.Unestimated, n.Name)
+			}
+		}
+	}
+	return shortfalls, plan
+}
@@ -0,0 +1,44 @@
+package bdo
+
+import "testing"
+
+func TestGoalSplitsOutput(t *testing.T) {
+	g := testGraph(t)
+	name := "Mariveno Island: A"
+	n := g.Node(name)
+	for _, c := range []string{name, "Mariveno Island", "Luivano Island"} {
+		g.Node(c).Owned = true
+	}
+	n.AssignedWorker = "Velia"
+	n.Workload = 100
+	n.Distance = 1800
+	perDay, _ := g.PerDay(name, "Velia")
+	want := perDay / float64(len(n.Produces))
+	shortfalls, _ := g.Goal(map[string]float64{n.Produces[0]: 1e6, n.Produces[1]: 1e6})
+	for _, s := range shortfalls {
+		if s.Have != want {
+			t.Errorf("%s: have %v, not %v", s.Item, s.Have, want)
+		}
+	}
+}
+
+func TestGoalUnestimated(t *testing.T) {
+	g := testGraph(t)
+	name := "Mariveno Island: A"
+	n := g.Node(name)
+	for _, c := range []string{name, "Mariveno Island", "Luivano Island"} {
+		g.Node(c).Owned = true
+	}
+	n.AssignedWorker = "Velia"
+	shortfalls, plan := g.Goal(map[string]float64{n.Produces[0]: 10})
+	if len(plan) != 0 {
+		t.Errorf("planned %d purchases without any estimates", len(plan))
+	}
+	s := shortfalls[0]
+	if s.Have != 0 || s.Planned != 0 || s.Short != 10 {
+		t.Errorf("have %v, planned %v, short %v", s.Have, s.Planned, s.Short)
+	}
+	if len(s.Unestimated) == 0 || s.Unestimated[0] != name {
+		t.Errorf("unestimated was %v", s.Unestimated)
+	}
+}
@@ -0,0 +1,291 @@
+// Package bdo is a small Black Desert Online database: the node network, what
+// the production nodes produce, and the contribution point costs of
+// connecting things to the nodes you own.
+package bdo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Graph is the node network. The zero value is not usable; use NewGraph or
+// one of the Load functions.
+type Graph struct {
+	nodes       map[string]*Node
+	connections map[string]map[string]struct{}
+	tradeBonus  *TradeBonus
+}
+
+// Node is a single node in a Graph. Kind is one of the Kinds. Region is the
+// large area the node is in, such as Balenos, and Territory is the town whose
+// area it is in. Worker is the type and stats of the assigned worker, if
+// known. Workload and Distance, from the ClosestWorker town, are used to
+// estimate production and are zero if not known. Position is where the node
+// is on the world map, if known.
+type Node struct {
+	Name               string    `json:"name"`
+	ContributionPoints int       `json:"cp"`
+	Kind               string    `json:"kind"`
+	Region             string    `json:"region,omitempty"`
+	Territory          string    `json:"territory,omitempty"`
+	Position           *Position `json:"position,omitempty"`
+	Owned              bool      `json:"owned"`
+	ClosestWorker      string    `json:"closestWorker,omitempty"`
+	AssignedWorker     string    `json:"assignedWorker,omitempty"`
+	Worker             *Worker   `json:"worker,omitempty"`
+	Produces           []string  `json:"produces,omitempty"`
+	Workload           int       `json:"workload,omitempty"`
+	Distance           float64   `json:"distance,omitempty"`
+}
+
+// The kinds of nodes. Excavation nodes, which produce relic materials, are
+// specialty nodes, and nodes with a trade manager are trade nodes even if
+// they also have production nodes.
+const (
+	KindTown       = "town"
+	KindConnection = "connection"
+	KindProduction = "production"
+	KindTrade      = "trade"
+	KindDanger     = "danger"
+	KindSpecialty  = "specialty"
+)
+
+// Kinds lists all the kinds of nodes.
+var Kinds = []string{KindTown, KindConnection, KindProduction, KindTrade, KindDanger, KindSpecialty}
+
+// NewGraph returns an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{
+		nodes:       map[string]*Node{},
+		connections: map[string]map[string]struct{}{},
+	}
+}
+
+// Clone returns a copy of the graph that can be changed, such as with
+// different owned nodes, without affecting the original.
+func (g *Graph) Clone() *Graph {
+	c := NewGraph()
+	if g.tradeBonus != nil {
+		tb := *g.tradeBonus
+		c.tradeBonus = &tb
+	}
+	for name, n := range g.nodes {
+		n2 := *n
+		n2.Produces = append([]string(nil), n.Produces...)
+		if n.Worker != nil {
+			w := *n.Worker
+			n2.Worker = &w
+		}
+		if n.Position != nil {
+			p := *n.Position
+			n2.Position = &p
+		}
+		c.nodes[name] = &n2
+	}
+	for a, bs := range g.connections {
+		c.connections[a] = make(map[string]struct{}, len(bs))
+		for b := range bs {
+			c.connections[a][b] = struct{}{}
+		}
+	}
+	return c
+}
+
+// AddNode adds, or replaces, the named node. Nodes with no contribution point
+// cost, such as towns, are always owned. The node's Kind starts as a town if
+// it has no cost and a connection otherwise.
+func (g *Graph) AddNode(name string, cp int) *Node {
+	n := &Node{Name: name, ContributionPoints: cp, Kind: KindConnection}
+	if cp == 0 {
+		n.Owned = true
+		n.Kind = KindTown
+	}
+	g.nodes[name] = n
+	return n
+}
+
+// AddProductionNode adds the production node "<parent>: <name>" and connects
+// it to its parent. It is in the same region and territory as its parent.
+func (g *Graph) AddProductionNode(parent string, name string, cp int, closestWorker string, produces ...string) *Node {
+	n := g.AddNode(parent+": "+name, cp)
+	n.Kind = KindProduction
+	if p := g.nodes[parent]; p != nil {
+		n.Region = p.Region
+		n.Territory = p.Territory
+	}
+	n.ClosestWorker = closestWorker
+	n.Produces = append(n.Produces, produces...)
+	g.AddConnection(parent, n.Name)
+	g.AddConnection(n.Name, parent)
+	return n
+}
+
+// AddConnection adds the one way connection from a to b; connections are
+// expected to be added from both ends.
+func (g *Graph) AddConnection(a string, b string) {
+	if _, ok := g.connections[a]; !ok {
+		g.connections[a] = map[string]struct{}{b: struct{}{}}
+	} else {
+		g.connections[a][b] = struct{}{}
+	}
+}
+
+// Node returns the node with exactly the name given, or nil.
+func (g *Graph) Node(name string) *Node {
+	return g.nodes[name]
+}
+
+// Find returns the node with the name given, ignoring case, or nil.
+func (g *Graph) Find(name string) *Node {
+	if n := g.nodes[name]; n != nil {
+		return n
+	}
+	nameL := strings.ToLower(name)
+	for n, node := range g.nodes {
+		if strings.ToLower(n) == nameL {
+			return node
+		}
+	}
+	return nil
+}
+
+// Nodes returns all the nodes sorted by name.
+func (g *Graph) Nodes() []*Node {
+	ns := make([]*Node, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		ns = append(ns, n)
+	}
+	sort.Slice(ns, func(i, j int) bool { return ns[i].Name < ns[j].Name })
+	return ns
+}
+
+// Regions returns the names of the regions of all the nodes, sorted.
+func (g *Graph) Regions() []string {
+	found := map[string]struct{}{}
+	for _, n := range g.nodes {
+		if n.Region != "" {
+			found[n.Region] = struct{}{}
+		}
+	}
+	rs := make([]string, 0, len(found))
+	for r := range found {
+		rs = append(rs, r)
+	}
+	sort.Strings(rs)
+	return rs
+}
+
+// InRegion returns the names of the nodes in the named region.
+func (g *Graph) InRegion(region string) map[string]struct{} {
+	found := map[string]struct{}{}
+	for name, n := range g.nodes {
+		if n.Region == region {
+			found[name] = struct{}{}
+		}
+	}
+	return found
+}
+
+// Connections returns the names of the nodes the named node connects to,
+// sorted.
+func (g *Graph) Connections(name string) []string {
+	cs := make([]string, 0, len(g.connections[name]))
+	for c := range g.connections[name] {
+		cs = append(cs, c)
+	}
+	sort.Strings(cs)
+	return cs
+}
+
+// Connected returns true if there is a connection from a to b.
+func (g *Graph) Connected(a string, b string) bool {
+	_, ok := g.connections[a][b]
+	return ok
+}
+
+func (n *Node) String() string {
+	var s string
+	if n.Owned {
+		s = fmt.Sprintf("%s (%d) owned", n.Name, n.ContributionPoints)
+	} else {
+		s = fmt.Sprintf("%s [%d]", n.Name, n.ContributionPoints)
+	}
+	if n.ClosestWorker != "" {
+		s += ", closest worker from " + n.ClosestWorker
+	}
+	if len(n.Produces) > 0 {
+		s += ", produces:"
+		for i, p := range n.Produces {
+			if i != 0 {
+				s += ","
+			}
+			s += " " + p
+		}
+	}
+	return s
+}
+
+// Cutoff returns the owned nodes, sorted, that would no longer be connected
+// to any town through owned nodes if the named node were not owned. Nodes
+// already cut off are not included.
+func (g *Graph) Cutoff(name string) []string {
+	before := g.reachable("")
+	after := g.reachable(name)
+	var cut []string
+	for n := range before {
+		if _, ok := after[n]; !ok && n != name {
+			cut = append(cut, n)
+		}
+	}
+	sort.Strings(cut)
+	return cut
+}
+
+// reachable returns the owned nodes connected to a town through owned nodes,
+// acting as if the excluded node were not owned.
+func (g *Graph) reachable(exclude string) map[string]struct{} {
+	found := map[string]struct{}{}
+	var edge []string
+	for name, n := range g.nodes {
+		if n.Kind == KindTown && name != exclude {
+			found[name] = struct{}{}
+			edge = append(edge, name)
+		}
+	}
+	for len(edge) > 0 {
+		var next []string
+		for _, n := range edge {
+			for c := range g.connections[n] {
+				if _, ok := found[c]; ok || c == exclude || g.nodes[c] == nil || !g.nodes[c].Owned {
+					continue
+				}
+				found[c] = struct{}{}
+				next = append(next, c)
+			}
+		}
+		edge = next
+	}
+	return found
+}
+
+// hops returns the number of connections from the named node to each node
+// reachable from it, only travelling through owned nodes if ownedOnly is set.
+func (g *Graph) hops(name string, ownedOnly bool) map[string]int {
+	hops := map[string]int{name: 0}
+	edge := []string{name}
+	for h := 1; len(edge) > 0; h++ {
+		var next []string
+		for _, n := range edge {
+			for c := range g.connections[n] {
+				if _, ok := hops[c]; ok || g.nodes[c] == nil || (ownedOnly && !g.nodes[c].Owned) {
+					continue
+				}
+				hops[c] = h
+				next = append(next, c)
+			}
+		}
+		edge = next
+	}
+	return hops
+}
@@ -0,0 +1,121 @@
+package bdo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Item is something the production nodes produce.
+type Item struct {
+	Name string `json:"name"`
+	// Nodes are the names of the nodes producing the item, sorted.
+	Nodes []string `json:"nodes"`
+}
+
+// Items returns every item produced by the nodes, sorted by name.
+func (g *Graph) Items() []*Item {
+	found := map[string]*Item{}
+	for _, n := range g.Nodes() {
+		for _, p := range n.Produces {
+			item := found[p]
+			if item == nil {
+				item = &Item{Name: p}
+				found[p] = item
+			}
+			item.Nodes = append(item.Nodes, n.Name)
+		}
+	}
+	items := make([]*Item, 0, len(found))
+	for _, item := range found {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+// ResolveItem returns the item name refers to. The name may be the item's
+// full name, ignoring case; or the start of exactly one item's name; or
+// within exactly one item's name. Otherwise an error lists the items that
+// matched, or the closest item names if none did.
+func (g *Graph) ResolveItem(name string) (*Item, error) {
+	items := g.Items()
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	found, err := resolveName("item", name, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.Name == found {
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+// Supplying returns the owned nodes with workers assigned that produce the
+// item, sorted by name.
+func (g *Graph) Supplying(item string) []*Node {
+	var ns []*Node
+	for _, n := range g.Nodes() {
+		if !n.Owned || n.AssignedWorker == "" {
+			continue
+		}
+		for _, p := range n.Produces {
+			if p == item {
+				ns = append(ns, n)
+				break
+			}
+		}
+	}
+	return ns
+}
+
+// resolveName returns the one of the names that name refers to, as
+// ResolveItem describes, with errors calling the names what they are, such as
+// "item".
+func resolveName(what string, name string, names []string) (string, error) {
+	nameL := strings.ToLower(strings.TrimSpace(name))
+	var prefixed []string
+	var contained []string
+	for _, n := range names {
+		nL := strings.ToLower(n)
+		switch {
+		case nL == nameL:
+			return n, nil
+		case nameL == "":
+		case strings.HasPrefix(nL, nameL):
+			prefixed = append(prefixed, n)
+		case strings.Contains(nL, nameL):
+			contained = append(contained, n)
+		}
+	}
+	for _, matches := range [][]string{prefixed, contained} {
+		if len(matches) == 1 {
+			return matches[0], nil
+		}
+		if len(matches) > resolveMatchesShown {
+			return "", fmt.Errorf("%q could be any of %s, and %d more.", name, quotedList(matches[:resolveMatchesShown], ","), len(matches)-resolveMatchesShown)
+		}
+		if len(matches) > 1 {
+			return "", fmt.Errorf("%q could be any of %s.", name, quotedList(matches, "and"))
+		}
+	}
+	nameR := []rune(nameL)
+	distances := map[string]int{}
+	sorted := append([]string(nil), names...)
+	for _, n := range sorted {
+		distances[n] = editDistance(nameR, []rune(strings.ToLower(n)))
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return distances[sorted[i]] < distances[sorted[j]] })
+	if len(sorted) > resolveSuggestions {
+		sorted = sorted[:resolveSuggestions]
+	}
+	if len(sorted) == 0 {
+		return "", fmt.Errorf("Could not find %s %q.", what, name)
+	}
+	return "", fmt.Errorf("Could not find %s %q; did you mean %s?", what, name, quotedList(sorted, "or"))
+}
@@ -0,0 +1,229 @@
+package bdo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Problem is one problem with node data found by Lint.
+type Problem struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// The kinds of Problem. Load refuses data with ProblemDangling,
+// ProblemOneWay, or ProblemUnknownKind problems; the others are allowed but
+// are likely mistakes or missing data.
+const (
+	ProblemDangling     = "dangling"
+	ProblemOneWay       = "one-way"
+	ProblemUnknownKind  = "unknown-kind"
+	ProblemDuplicate    = "duplicate"
+	ProblemNoProduces   = "no-produces"
+	ProblemDisconnected = "disconnected"
+	ProblemTowns        = "unreachable-towns"
+)
+
+// LintReport is the result of Lint.
+type LintReport struct {
+	Problems []*Problem `json:"problems"`
+	// MissingConnections is how many "missingConnections" entries the data
+	// has, which are connections to nodes that have not been entered yet.
+	MissingConnections int `json:"missingConnections"`
+	// MissingWorkloads is how many production nodes have no "workload" or
+	// "distance", so their output cannot be estimated.
+	MissingWorkloads int `json:"missingWorkloads"`
+}
+
+// lintListed is how many node names a Problem lists before just counting the
+// rest.
+const lintListed = 5
+
+// LintDefault checks the node data shipped with this package; see Lint.
+func LintDefault() (*LintReport, error) {
+	return Lint(bytes.NewReader(defaultNodesData))
+}
+
+// LintFile checks the node data in the named file; see Lint.
+func LintFile(filename string) (*LintReport, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	report, err := Lint(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return report, nil
+}
+
+// Lint checks the node data read from r, in the format Load reads, and
+// returns every problem found with it rather than just the first. An error is
+// only returned if the data cannot be read at all.
+func Lint(r io.Reader) (*LintReport, error) {
+	var data nodesData
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	return data.lint(), nil
+}
+
+func (data *nodesData) lint() *LintReport {
+	report := &LintReport{Problems: []*Problem{}}
+	add := func(kind string, format string, args ...interface{}) {
+		report.Problems = append(report.Problems, &Problem{Kind: kind, Message: fmt.Sprintf(format, args...)})
+	}
+	// connections includes the production nodes, connected both ways to
+	// their parents just as AddProductionNode does.
+	connections := map[string][]string{}
+	var names []string
+	towns := map[string]struct{}{}
+	seen := map[string]int{}
+	for _, nd := range data.Nodes {
+		seen[nd.Name]++
+		if seen[nd.Name] == 2 {
+			add(ProblemDuplicate, "%s is listed more than once.", nd.Name)
+		}
+		if seen[nd.Name] == 1 {
+			names = append(names, nd.Name)
+		}
+		if nd.Kind != "" && !validKind(nd.Kind) {
+			add(ProblemUnknownKind, "%s has unknown kind %q.", nd.Name, nd.Kind)
+		}
+		if nd.Kind == KindTown || (nd.Kind == "" && nd.CP == 0) {
+			towns[nd.Name] = struct{}{}
+		}
+		seenProduction := map[string]int{}
+		for _, pd := range nd.Production {
+			name := nd.Name + ": " + pd.Name
+			seenProduction[pd.Name]++
+			if seenProduction[pd.Name] == 2 {
+				add(ProblemDuplicate, "%s is listed more than once.", name)
+			}
+			if seenProduction[pd.Name] == 1 {
+				names = append(names, name)
+			}
+			if len(pd.Produces) == 0 {
+				add(ProblemNoProduces, "%s produces nothing.", name)
+			}
+			if pd.Workload <= 0 || pd.Distance <= 0 {
+				report.MissingWorkloads++
+			}
+			connections[nd.Name] = append(connections[nd.Name], name)
+			connections[name] = append(connections[name], nd.Name)
+		}
+		connections[nd.Name] = append(connections[nd.Name], nd.Connections...)
+		report.MissingConnections += len(nd.MissingConnections)
+	}
+	exists := map[string]struct{}{}
+	for _, name := range names {
+		exists[name] = struct{}{}
+	}
+	connected := func(a string, b string) bool {
+		for _, c := range connections[a] {
+			if c == b {
+				return true
+			}
+		}
+		return false
+	}
+	for _, name := range names {
+		checked := map[string]struct{}{}
+		for _, c := range connections[name] {
+			if _, ok := checked[c]; ok {
+				continue
+			}
+			checked[c] = struct{}{}
+			if _, ok := exists[c]; !ok {
+				add(ProblemDangling, "%s lists %s, which is not a node.", name, c)
+			} else if !connected(c, name) {
+				add(ProblemOneWay, "%s lists %s, but %s does not list %s.", name, c, c, name)
+			}
+		}
+	}
+	// Components are found treating every connection as both ways, so
+	// one-way connections are only reported once.
+	both := map[string]map[string]struct{}{}
+	for a, cs := range connections {
+		for _, b := range cs {
+			if _, ok := exists[b]; !ok {
+				continue
+			}
+			if both[a] == nil {
+				both[a] = map[string]struct{}{}
+			}
+			if both[b] == nil {
+				both[b] = map[string]struct{}{}
+			}
+			both[a][b] = struct{}{}
+			both[b][a] = struct{}{}
+		}
+	}
+	component := map[string]int{}
+	var components [][]string
+	for _, name := range names {
+		if _, ok := component[name]; ok {
+			continue
+		}
+		members := []string{name}
+		component[name] = len(components)
+		for i := 0; i < len(members); i++ {
+			for c := range both[members[i]] {
+				if _, ok := component[c]; !ok {
+					component[c] = len(components)
+					members = append(members, c)
+				}
+			}
+		}
+		sort.Strings(members)
+		components = append(components, members)
+	}
+	// The main network is the component with the most towns, or the most
+	// nodes if none have towns.
+	townCount := func(members []string) int {
+		count := 0
+		for _, m := range members {
+			if _, ok := towns[m]; ok {
+				count++
+			}
+		}
+		return count
+	}
+	network := 0
+	for i, members := range components {
+		if tc, nc := townCount(members), townCount(components[network]); tc > nc || (tc == nc && len(members) > len(components[network])) {
+			network = i
+		}
+	}
+	for i, members := range components {
+		if i == network {
+			continue
+		}
+		add(ProblemDisconnected, "%d nodes are not connected to the rest: %s.", len(members), listed(members))
+		var cut []string
+		for _, m := range members {
+			if _, ok := towns[m]; ok {
+				cut = append(cut, m)
+			}
+		}
+		if len(cut) > 0 {
+			add(ProblemTowns, "%s cannot reach the other %d towns.", listed(cut), len(towns)-len(cut))
+		}
+	}
+	return report
+}
+
+// listed returns up to lintListed of the names, separated by commas, and how
+// many more there are.
+func listed(names []string) string {
+	if len(names) > lintListed {
+		return fmt.Sprintf("%s, and %d more", strings.Join(names[:lintListed], ", "), len(names)-lintListed)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,96 @@
+package bdo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LoadOwned marks the nodes listed in r as owned, one node per line. If a
+// line ends with " -- <worker town>" the node is also marked as having a
+// worker assigned to it from that town, and that may be followed by
+// " -- <worker>" giving the worker's type and stats as ParseWorker reads them.
+// Names are matched with Resolve.
+func (g *Graph) LoadOwned(r io.Reader) error {
+	_, err := g.loadOwned(r, false)
+	return err
+}
+
+// LoadOwnedLenient is LoadOwned but skips lines with names that cannot be
+// resolved, returning an error for each such line as a warning.
+func (g *Graph) LoadOwnedLenient(r io.Reader) ([]error, error) {
+	return g.loadOwned(r, true)
+}
+
+func (g *Graph) loadOwned(r io.Reader, lenient bool) ([]error, error) {
+	var warnings []error
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		name := line
+		var worker string
+		var stats *Worker
+		t := strings.SplitN(line, " -- ", 3)
+		if len(t) == 3 {
+			var err error
+			if stats, err = ParseWorker(t[2]); err != nil {
+				err = fmt.Errorf("On line %d, %q: %s", lineNumber, line, err)
+				if !lenient {
+					return warnings, err
+				}
+				warnings = append(warnings, err)
+				continue
+			}
+		}
+		if len(t) > 1 {
+			name = t[0]
+			w, err := g.Resolve(t[1])
+			if err != nil {
+				err = fmt.Errorf("On line %d, %q: %s", lineNumber, line, err)
+				if !lenient {
+					return warnings, err
+				}
+				warnings = append(warnings, err)
+				continue
+			}
+			worker = w.Name
+		}
+		n, err := g.Resolve(name)
+		if err != nil {
+			err = fmt.Errorf("On line %d, %q: %s", lineNumber, line, err)
+			if !lenient {
+				return warnings, err
+			}
+			warnings = append(warnings, err)
+			continue
+		}
+		n.Owned = true
+		if worker != "" {
+			n.AssignedWorker = worker
+			n.Worker = stats
+		}
+	}
+	return warnings, scanner.Err()
+}
+
+// WriteOwned writes the owned nodes, other than those that are always owned,
+// in the format LoadOwned reads.
+func (g *Graph) WriteOwned(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	for _, n := range g.Nodes() {
+		if !n.Owned || n.ContributionPoints == 0 {
+			continue
+		}
+		if n.AssignedWorker != "" && n.Worker != nil {
+			fmt.Fprintf(b, "%s -- %s -- %s\n", n.Name, n.AssignedWorker, n.Worker)
+		} else if n.AssignedWorker != "" {
+			fmt.Fprintf(b, "%s -- %s\n", n.Name, n.AssignedWorker)
+		} else {
+			fmt.Fprintln(b, n.Name)
+		}
+	}
+	return b.Flush()
+}
@@ -0,0 +1,185 @@
+package bdo
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// bestPathsLimit is the most paths BestPaths returns.
+const bestPathsLimit = 10
+
+// BestPaths returns the lowest contribution point cost to connect nodeA to
+// nodeB, or to any owned node if nodeB is empty, along with the paths that
+// have that cost in the fewest connections, up to bestPathsLimit of them;
+// paths with the same cost but more connections are not included. Each path
+// starts with nodeA and the cost of a path is the sum of the contribution
+// points of its unowned nodes, so this is a node weighted Dijkstra's search.
+// Ties in cost are broken by the number of connections, which keeps the tied
+// predecessors of each node from forming loops through the owned nodes that
+// cost nothing.
+func (g *Graph) BestPaths(nodeA string, nodeB string) (int, [][]string) {
+	cost, pths, _ := g.BestPathsCount(nodeA, nodeB)
+	return cost, pths
+}
+
+// BestPathsCount is BestPaths that also returns how many paths there are
+// with the lowest cost in the fewest connections, which is more than the
+// paths returned if they were cut off at bestPathsLimit.
+func (g *Graph) BestPathsCount(nodeA string, nodeB string) (int, [][]string, int) {
+	weight := func(n string) int {
+		if g.nodes[n].Owned {
+			return 0
+		}
+		return g.nodes[n].ContributionPoints
+	}
+	// With no nodeB every owned node is a destination, and paths stop at the
+	// first one they reach rather than passing through it.
+	isEnd := func(n string) bool {
+		if n == nodeA {
+			return false
+		}
+		if nodeB == "" {
+			return g.nodes[n].Owned
+		}
+		return n == nodeB
+	}
+	dist := map[string]costName{nodeA: {cost: weight(nodeA), name: nodeA}}
+	prevs := map[string][]string{}
+	done := map[string]struct{}{}
+	q := &costQueue{&costName{cost: weight(nodeA), name: nodeA}}
+	for q.Len() > 0 {
+		cn := heap.Pop(q).(*costName)
+		if _, ok := done[cn.name]; ok {
+			continue
+		}
+		done[cn.name] = struct{}{}
+		if isEnd(cn.name) {
+			continue
+		}
+		for n2 := range g.connections[cn.name] {
+			if _, ok := done[n2]; ok || g.nodes[n2] == nil {
+				continue
+			}
+			d := costName{cost: cn.cost + weight(n2), hops: cn.hops + 1, name: n2}
+			d2, ok := dist[n2]
+			switch {
+			case !ok || d.less(&d2):
+				dist[n2] = d
+				prevs[n2] = []string{cn.name}
+				heap.Push(q, &d)
+			case d.cost == d2.cost && d.hops == d2.hops:
+				prevs[n2] = append(prevs[n2], cn.name)
+			}
+		}
+	}
+	var best *costName
+	var ends []string
+	for n, d := range dist {
+		if !isEnd(n) {
+			continue
+		}
+		d := d
+		if best == nil || d.less(best) {
+			best = &d
+			ends = ends[:0]
+		}
+		if d.cost == best.cost && d.hops == best.hops {
+			ends = append(ends, n)
+		}
+	}
+	if best == nil {
+		return int(^uint(0) >> 1), nil, 0
+	}
+	sort.Strings(ends)
+	var bestPaths [][]string
+	// Every predecessor has one fewer connection, so walking back always
+	// reaches nodeA.
+	var back func(pth []string, n string)
+	back = func(pth []string, n string) {
+		if len(bestPaths) >= bestPathsLimit {
+			return
+		}
+		pth = append(pth, n)
+		if n == nodeA {
+			npth := make([]string, len(pth))
+			for i, n := range pth {
+				npth[len(pth)-1-i] = n
+			}
+			bestPaths = append(bestPaths, npth)
+			return
+		}
+		ps := prevs[n]
+		sort.Strings(ps)
+		for _, p := range ps {
+			back(pth, p)
+		}
+	}
+	for _, n := range ends {
+		back(nil, n)
+	}
+	// counts are how many ways back to nodeA there are from each node,
+	// stopping at the largest int rather than overflowing.
+	maxCount := int(^uint(0) >> 1)
+	counts := map[string]int{nodeA: 1}
+	var count func(n string) int
+	count = func(n string) int {
+		if c, ok := counts[n]; ok {
+			return c
+		}
+		c := 0
+		for _, p := range prevs[n] {
+			if pc := count(p); pc > maxCount-c {
+				c = maxCount
+			} else {
+				c += pc
+			}
+		}
+		counts[n] = c
+		return c
+	}
+	total := 0
+	for _, n := range ends {
+		if c := count(n); c > maxCount-total {
+			total = maxCount
+		} else {
+			total += c
+		}
+	}
+	return best.cost, bestPaths, total
+}
+
+type costName struct {
+	cost int
+	hops int
+	name string
+}
+
+// less orders by cost and then by hops.
+func (cn *costName) less(cn2 *costName) bool {
+	return cn.cost < cn2.cost || (cn.cost == cn2.cost && cn.hops < cn2.hops)
+}
+
+type costQueue []*costName
+
+func (q costQueue) Len() int {
+	return len(q)
+}
+
+func (q costQueue) Swap(x, y int) {
+	q[x], q[y] = q[y], q[x]
+}
+
+func (q costQueue) Less(x, y int) bool {
+	return q[x].less(q[y])
+}
+
+func (q *costQueue) Push(x interface{}) {
+	*q = append(*q, x.(*costName))
+}
+
+func (q *costQueue) Pop() interface{} {
+	old := *q
+	cn := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return cn
+}
@@ -0,0 +1,229 @@
+package bdo
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// dfsBestPaths is the depth first search BestPaths replaced, kept to compare
+// against.
+func dfsBestPaths(g *Graph, nodeA string, nodeB string) (int, [][]string) {
+	deadends := map[string]struct{}{}
+	visited := map[string]struct{}{nodeA: {}}
+	cost := 0
+	bestCost := int(^uint(0) >> 1)
+	var bestPaths [][]string
+	var branch func(pth []string, n string) bool
+	branch = func(pth []string, n string) bool {
+		if !g.nodes[n].Owned {
+			cost += g.nodes[n].ContributionPoints
+		}
+		visited[n] = struct{}{}
+		defer func() {
+			if !g.nodes[n].Owned {
+				cost -= g.nodes[n].ContributionPoints
+			}
+			delete(visited, n)
+		}()
+		deadend := true
+		for n2 := range g.connections[n] {
+			if _, ok := deadends[n2]; ok {
+				continue
+			}
+			if _, ok := visited[n2]; ok {
+				continue
+			}
+			if n2 == nodeB || (nodeB == "" && g.nodes[n2].Owned) {
+				deadend = false
+				npth := make([]string, len(pth)+2)
+				copy(npth, pth)
+				npth[len(pth)] = n
+				npth[len(pth)+1] = n2
+				ncost := cost
+				if !g.nodes[n2].Owned {
+					ncost += g.nodes[n2].ContributionPoints
+				}
+				if bestCost > ncost {
+					bestCost = ncost
+					bestPaths = bestPaths[:0]
+					bestPaths = append(bestPaths, npth)
+				} else if bestCost == ncost {
+					bestPaths = append(bestPaths, npth)
+				}
+				continue
+			}
+			npth := make([]string, len(pth)+1)
+			copy(npth, pth)
+			npth[len(pth)] = n
+			if branch(npth, n2) {
+				deadend = false
+			}
+		}
+		if deadend {
+			deadends[n] = struct{}{}
+			return false
+		}
+		return true
+	}
+	branch(nil, nodeA)
+	return bestCost, bestPaths
+}
+
+// testGraph returns the default graph with every node in the regions given
+// owned.
+func testGraph(t testing.TB, regions ...string) *Graph {
+	g, err := DefaultGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range regions {
+		for name := range g.InRegion(r) {
+			g.nodes[name].Owned = true
+		}
+	}
+	return g
+}
+
+// pathCost returns the cost of the path as BestPaths counts it, failing if
+// it is not connected.
+func pathCost(t testing.TB, g *Graph, pth []string) int {
+	cost := 0
+	for i, n := range pth {
+		if i > 0 && !g.Connected(pth[i-1], n) {
+			t.Fatalf("%v is not connected at %s", pth, n)
+		}
+		if !g.nodes[n].Owned {
+			cost += g.nodes[n].ContributionPoints
+		}
+	}
+	return cost
+}
+
+func TestBestPathsOwnedNetwork(t *testing.T) {
+	g := testGraph(t, "Balenos", "Serendia", "Calpheon")
+	cost, pths := g.BestPaths("Velia", "Heidel")
+	if cost != 0 {
+		t.Errorf("cost was %d, not 0", cost)
+	}
+	if len(pths) == 0 || len(pths) > bestPathsLimit {
+		t.Fatalf("got %d paths", len(pths))
+	}
+	hops := len(pths[0])
+	for _, pth := range pths {
+		if pth[0] != "Velia" || pth[len(pth)-1] != "Heidel" {
+			t.Errorf("%v does not go from Velia to Heidel", pth)
+		}
+		if len(pth) != hops {
+			t.Errorf("%v is not as short as %v", pth, pths[0])
+		}
+		if c := pathCost(t, g, pth); c != cost {
+			t.Errorf("%v costs %d, not %d", pth, c, cost)
+		}
+	}
+}
+
+func TestBestPathsNoMoreThanDFS(t *testing.T) {
+	g := testGraph(t)
+	nodes := g.Nodes()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range nodes {
+		if n.ContributionPoints > 0 && rnd.Intn(3) == 0 {
+			n.Owned = true
+		}
+	}
+	for i := 0; i < 200; i++ {
+		a := nodes[rnd.Intn(len(nodes))].Name
+		b := ""
+		if i%2 == 1 {
+			b = nodes[rnd.Intn(len(nodes))].Name
+		}
+		if a == b {
+			continue
+		}
+		cost, pths := g.BestPaths(a, b)
+		dfsCost, _ := dfsBestPaths(g, a, b)
+		if cost > dfsCost {
+			t.Errorf("%q to %q cost %d, more than %d", a, b, cost, dfsCost)
+		}
+		for _, pth := range pths {
+			if c := pathCost(t, g, pth); c != cost {
+				t.Errorf("%v costs %d, not %d", pth, c, cost)
+			}
+		}
+	}
+}
+
+func TestBestPathsCount(t *testing.T) {
+	g := testGraph(t)
+	nodes := g.Nodes()
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		a, b := nodes[rnd.Intn(len(nodes))].Name, nodes[rnd.Intn(len(nodes))].Name
+		if a == b {
+			continue
+		}
+		_, pths, total := g.BestPathsCount(a, b)
+		if want := min(total, bestPathsLimit); len(pths) != want {
+			t.Errorf("%q to %q gave %d paths of %d", a, b, len(pths), total)
+		}
+		seen := map[string]struct{}{}
+		for _, pth := range pths {
+			key := strings.Join(pth, "|")
+			if _, ok := seen[key]; ok {
+				t.Errorf("%q to %q gave %v more than once", a, b, pth)
+			}
+			seen[key] = struct{}{}
+		}
+	}
+	// Four diamonds in a row, each of which can be crossed two ways at the
+	// same cost, make 16 tied paths.
+	g = NewGraph()
+	g.AddNode("0", 1)
+	for i := 1; i <= 4; i++ {
+		prev, next := strconv.Itoa(i-1), strconv.Itoa(i)
+		g.AddNode(next, 1)
+		for _, side := range []string{"a", "b"} {
+			g.AddNode(next+side, 1)
+			g.AddConnection(prev, next+side)
+			g.AddConnection(next+side, next)
+		}
+	}
+	cost, pths, total := g.BestPathsCount("0", "4")
+	if cost != 9 || total != 16 || len(pths) != bestPathsLimit {
+		t.Errorf("cost %d, total %d, with %d paths", cost, total, len(pths))
+	}
+}
+
+func BenchmarkBestPaths(b *testing.B) {
+	g := testGraph(b)
+	nodes := g.Nodes()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range nodes {
+		if n.ContributionPoints > 0 && rnd.Intn(3) == 0 {
+			n.Owned = true
+		}
+	}
+	var pairs [][2]string
+	for len(pairs) < 100 {
+		a, c := nodes[rnd.Intn(len(nodes))].Name, nodes[rnd.Intn(len(nodes))].Name
+		if a != c {
+			pairs = append(pairs, [2]string{a, c})
+		}
+	}
+	b.Run("Dijkstra", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, p := range pairs {
+				g.BestPaths(p[0], p[1])
+			}
+		}
+	})
+	b.Run("DFS", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, p := range pairs {
+				dfsBestPaths(g, p[0], p[1])
+			}
+		}
+	})
+}
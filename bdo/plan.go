@@ -0,0 +1,95 @@
+package bdo
+
+import "sort"
+
+// Purchase is one step of a Plan: a production node along with the unowned
+// nodes needed to connect it, in the order they should be bought.
+type Purchase struct {
+	Node  string   `json:"node"`
+	Buy   []string `json:"buy"`
+	Cost  int      `json:"cost"`
+	Value float64  `json:"value"`
+	// Items are the items this node adds to what is already produced.
+	Items []string `json:"items"`
+}
+
+// Plan chooses unowned production nodes to buy, along with the nodes needed
+// to connect them, to get the most value for at most budget contribution
+// points. The value of a production node is the total of the weights of the
+// items it produces that nothing owned, or already planned, produces. If
+// weights is nil every item is worth 1; otherwise items not in weights are
+// worth nothing.
+//
+// This is a greedy plan, taking the best value per contribution point each
+// step, so it can miss the very best combination but runs quickly.
+func (g *Graph) Plan(budget int, weights map[string]float64) []*Purchase {
+	weight := func(item string) float64 {
+		if weights == nil {
+			return 1
+		}
+		return weights[item]
+	}
+	g = g.Clone()
+	have := map[string]struct{}{}
+	for _, n := range g.nodes {
+		if n.Owned {
+			for _, p := range n.Produces {
+				have[p] = struct{}{}
+			}
+		}
+	}
+	var plan []*Purchase
+	for {
+		var best *Purchase
+		for _, n := range g.Nodes() {
+			if n.Owned || len(n.Produces) == 0 {
+				continue
+			}
+			var items []string
+			value := 0.0
+			for _, p := range n.Produces {
+				if _, ok := have[p]; ok {
+					continue
+				}
+				if w := weight(p); w > 0 {
+					items = append(items, p)
+					value += w
+				}
+			}
+			if value == 0 {
+				continue
+			}
+			cost, pths := g.BestPaths(n.Name, "")
+			if len(pths) == 0 || cost > budget {
+				continue
+			}
+			if best != nil {
+				a := value * float64(best.Cost)
+				b := best.Value * float64(cost)
+				if a < b || (a == b && value <= best.Value) {
+					continue
+				}
+			}
+			best = &Purchase{Node: n.Name, Cost: cost, Value: value, Items: items}
+			pth := pths[0]
+			for i := len(pth) - 1; i >= 0; i-- {
+				if !g.nodes[pth[i]].Owned {
+					best.Buy = append(best.Buy, pth[i])
+				}
+			}
+		}
+		if best == nil {
+			break
+		}
+		for _, n := range best.Buy {
+			g.nodes[n].Owned = true
+		}
+		for _, p := range g.nodes[best.Node].Produces {
+			have[p] = struct{}{}
+		}
+		sort.Strings(best.Items)
+		budget -= best.Cost
+		plan = append(plan, best)
+	}
+	return plan
+}
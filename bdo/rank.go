@@ -0,0 +1,81 @@
+package bdo
+
+import "sort"
+
+// Rank is the expected silver a production node earns for its contribution
+// points.
+type Rank struct {
+	Node  string `json:"node"`
+	Owned bool   `json:"owned"`
+	// Item is the best priced item the node produces, the one to work it for.
+	Item string `json:"item"`
+	// Cost is the contribution points to buy the node and connect it to the
+	// network, or just the node's own contribution points if it is owned.
+	Cost          int     `json:"cost"`
+	SilverPerHour float64 `json:"silverPerHour"`
+	// SilverPerCP is SilverPerHour divided by Cost, or just SilverPerHour if
+	// Cost is 0.
+	SilverPerCP float64 `json:"silverPerCP"`
+	// Estimated is false if the node's output could not be estimated, so
+	// SilverPerHour and SilverPerCP are 0.
+	Estimated bool `json:"estimated"`
+}
+
+// Rank returns the production nodes that produce any of the priced items,
+// and can be connected to the network, by their silver per hour per
+// contribution point, best first. Output is estimated as for Goal, worked
+// from the node's assigned worker town or else its closest worker town, and
+// each cycle earns the price of the node's best priced item. Nodes whose
+// output could not be estimated are listed after all those that could, by
+// Cost, as there is nothing to rank them by.
+func (g *Graph) Rank(prices map[string]float64) []*Rank {
+	var ranks []*Rank
+	for _, n := range g.Nodes() {
+		var item string
+		price := 0.0
+		for _, p := range n.Produces {
+			if prices[p] > price {
+				item = p
+				price = prices[p]
+			}
+		}
+		if price <= 0 {
+			continue
+		}
+		r := &Rank{Node: n.Name, Owned: n.Owned, Item: item, Cost: n.ContributionPoints}
+		if !n.Owned {
+			cost, pths := g.BestPaths(n.Name, "")
+			if len(pths) == 0 {
+				continue
+			}
+			r.Cost = cost
+		}
+		town := n.ClosestWorker
+		if n.AssignedWorker != "" {
+			town = n.AssignedWorker
+		}
+		var perDay float64
+		perDay, r.Estimated = g.PerDay(n.Name, town)
+		if !r.Estimated {
+			ranks = append(ranks, r)
+			continue
+		}
+		r.SilverPerHour = perDay / 24 * price
+		if r.Cost > 0 {
+			r.SilverPerCP = r.SilverPerHour / float64(r.Cost)
+		} else {
+			r.SilverPerCP = r.SilverPerHour
+		}
+		ranks = append(ranks, r)
+	}
+	sort.SliceStable(ranks, func(i, j int) bool {
+		if ranks[i].Estimated != ranks[j].Estimated {
+			return ranks[i].Estimated
+		}
+		if !ranks[i].Estimated {
+			return ranks[i].Cost < ranks[j].Cost
+		}
+		return ranks[i].SilverPerCP > ranks[j].SilverPerCP
+	})
+	return ranks
+}
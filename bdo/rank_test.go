@@ -0,0 +1,31 @@
+package bdo
+
+import "testing"
+
+func TestRank(t *testing.T) {
+	g := testGraph(t, "Balenos")
+	n := g.Node("Mariveno Island: A")
+	n.Workload = 100
+	n.Distance = 1800
+	prices := map[string]float64{n.Produces[0]: 100, n.Produces[1]: 300, n.Produces[2]: 200}
+	ranks := g.Rank(prices)
+	if len(ranks) == 0 || ranks[0].Node != n.Name {
+		t.Fatalf("%s, the only estimated node, was not ranked first", n.Name)
+	}
+	r := ranks[0]
+	if r.Item != n.Produces[1] {
+		t.Errorf("%s was ranked for %s, not %s", r.Node, r.Item, n.Produces[1])
+	}
+	perDay, _ := g.PerDay(r.Node, n.ClosestWorker)
+	if want := perDay / 24 * 300; !r.Estimated || r.SilverPerHour != want {
+		t.Errorf("%s earns %v silver per hour, not %v", r.Node, r.SilverPerHour, want)
+	}
+	for i, r := range ranks[1:] {
+		if r.Estimated || r.SilverPerHour != 0 {
+			t.Errorf("%s was estimated at %v silver per hour", r.Node, r.SilverPerHour)
+		}
+		if i > 0 && r.Cost < ranks[i].Cost {
+			t.Errorf("%s costs less than %s before it", r.Node, ranks[i].Node)
+		}
+	}
+}
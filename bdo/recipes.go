@@ -0,0 +1,282 @@
+package bdo
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+//go:embed recipes.json
+var defaultRecipesData []byte
+
+// The kinds of Recipe: the processing kinds, then cooking and alchemy.
+const (
+	RecipeChopping  = "chopping"
+	RecipeHeating   = "heating"
+	RecipeGrinding  = "grinding"
+	RecipeDrying    = "drying"
+	RecipeFiltering = "filtering"
+	RecipeShaking   = "shaking"
+	RecipeCooking   = "cooking"
+	RecipeAlchemy   = "alchemy"
+)
+
+// RecipeKinds are all the kinds of Recipe.
+var RecipeKinds = []string{RecipeChopping, RecipeHeating, RecipeGrinding, RecipeDrying, RecipeFiltering, RecipeShaking, RecipeCooking, RecipeAlchemy}
+
+// Recipe is how an item is made from other items. Makes is how many of the
+// item one craft makes, on average.
+type Recipe struct {
+	Name        string        `json:"name"`
+	Kind        string        `json:"kind"`
+	Makes       float64       `json:"makes"`
+	Ingredients []*Ingredient `json:"ingredients"`
+}
+
+// Ingredient is how many of an item one craft of a Recipe uses.
+type Ingredient struct {
+	Item  string  `json:"item"`
+	Count float64 `json:"count"`
+}
+
+// Recipes are the recipes loaded from recipe data, by the name of the item
+// each makes, and the items that are bought rather than gathered or made.
+type Recipes struct {
+	recipes map[string]*Recipe
+	bought  map[string]struct{}
+}
+
+// recipesData is the format of the recipe data file. It is a JSON object
+// with a "recipes" list and a "bought" list of the items bought from vendors,
+// such as Mineral Water. For example:
+//
+//	{
+//		"bought": ["Mineral Water", "Sugar"],
+//		"recipes": [
+//			{
+//				"name": "Copper Ingot",
+//				"kind": "heating",
+//				"makes": 1,
+//				"ingredients": [
+//					{
+//						"item": "Copper Ore",
+//						"count": 5
+//					}
+//				]
+//			}
+//		]
+//	}
+//
+// The "kind" is one of the RecipeKinds and "makes" defaults to 1. Only one
+// recipe may make each item, and recipes may not need what they make, even
+// through other recipes. Items with no recipe that are not bought are raw
+// items, which come from the node data's "produces" lists.
+//
+// The quantities in the built in recipes.json are placeholders with no cited
+// source, not checked against the game, so they should be corrected in a
+// copy where they differ from it.
+type recipesData struct {
+	Bought  []string  `json:"bought"`
+	Recipes []*Recipe `json:"recipes"`
+}
+
+// DefaultRecipes returns the recipe data shipped with this package, whose
+// quantities are placeholders; see recipesData.
+func DefaultRecipes() (*Recipes, error) {
+	rs, err := LoadRecipes(bytes.NewReader(defaultRecipesData))
+	if err != nil {
+		return nil, fmt.Errorf("default recipe data: %s", err)
+	}
+	return rs, nil
+}
+
+// LoadRecipesFile returns the recipe data in the named file; see
+// LoadRecipes.
+func LoadRecipesFile(filename string) (*Recipes, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	rs, err := LoadRecipes(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return rs, nil
+}
+
+// LoadRecipes returns the recipe data read from r. The data is the same
+// format as the recipes.json file shipped with this package.
+func LoadRecipes(r io.Reader) (*Recipes, error) {
+	var data recipesData
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	rs := &Recipes{recipes: map[string]*Recipe{}, bought: map[string]struct{}{}}
+	for _, b := range data.Bought {
+		rs.bought[b] = struct{}{}
+	}
+	for _, rc := range data.Recipes {
+		if _, ok := rs.recipes[rc.Name]; ok {
+			return nil, fmt.Errorf("%s has more than one recipe.", rc.Name)
+		}
+		if !validRecipeKind(rc.Kind) {
+			return nil, fmt.Errorf("%s has unknown kind %q.", rc.Name, rc.Kind)
+		}
+		if rc.Makes == 0 {
+			rc.Makes = 1
+		}
+		if rc.Makes < 0 {
+			return nil, fmt.Errorf("%s makes %g.", rc.Name, rc.Makes)
+		}
+		if len(rc.Ingredients) == 0 {
+			return nil, fmt.Errorf("%s has no ingredients.", rc.Name)
+		}
+		for _, in := range rc.Ingredients {
+			if in.Count <= 0 {
+				return nil, fmt.Errorf("%s needs %g %s.", rc.Name, in.Count, in.Item)
+			}
+		}
+		if _, ok := rs.bought[rc.Name]; ok {
+			return nil, fmt.Errorf("%s is both bought and has a recipe.", rc.Name)
+		}
+		rs.recipes[rc.Name] = rc
+	}
+	// Each recipe is walked down to its raw items, failing if it comes back
+	// to an item already on the way down.
+	done := map[string]struct{}{}
+	var walk func(name string, path []string) error
+	walk = func(name string, path []string) error {
+		rc := rs.recipes[name]
+		if rc == nil {
+			return nil
+		}
+		if _, ok := done[name]; ok {
+			return nil
+		}
+		for _, p := range path {
+			if p == name {
+				return fmt.Errorf("%s needs itself: %s.", name, strings.Join(append(path, name), " -> "))
+			}
+		}
+		for _, in := range rc.Ingredients {
+			if err := walk(in.Item, append(path, name)); err != nil {
+				return err
+			}
+		}
+		done[name] = struct{}{}
+		return nil
+	}
+	for _, name := range rs.Names() {
+		if err := walk(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return rs, nil
+}
+
+func validRecipeKind(kind string) bool {
+	for _, k := range RecipeKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Recipe returns the recipe making the named item, or nil.
+func (rs *Recipes) Recipe(item string) *Recipe {
+	return rs.recipes[item]
+}
+
+// Names returns the names of the items with recipes, sorted.
+func (rs *Recipes) Names() []string {
+	names := make([]string, 0, len(rs.recipes))
+	for name := range rs.recipes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Bought returns true if the item is bought from vendors.
+func (rs *Recipes) Bought(item string) bool {
+	_, ok := rs.bought[item]
+	return ok
+}
+
+// Resolve returns the name of the item with a recipe that name refers to, as
+// Graph.ResolveItem matches items.
+func (rs *Recipes) Resolve(name string) (string, error) {
+	return resolveName("recipe", name, rs.Names())
+}
+
+// ResolveItem returns the name of the item name refers to, as
+// Graph.ResolveItem matches items, out of the items with recipes, the bought
+// items, and the items the nodes of g produce.
+func (rs *Recipes) ResolveItem(g *Graph, name string) (string, error) {
+	found := map[string]struct{}{}
+	for item := range rs.recipes {
+		found[item] = struct{}{}
+	}
+	for item := range rs.bought {
+		found[item] = struct{}{}
+	}
+	for _, item := range g.Items() {
+		found[item.Name] = struct{}{}
+	}
+	names := make([]string, 0, len(found))
+	for item := range found {
+		names = append(names, item)
+	}
+	sort.Strings(names)
+	return resolveName("item", name, names)
+}
+
+// RecipeTree is an item and how many are needed, with the Recipe making it
+// and the trees of its ingredients; Recipe is nil for raw and bought items.
+type RecipeTree struct {
+	Item        string        `json:"item"`
+	Count       float64       `json:"count"`
+	Recipe      *Recipe       `json:"-"`
+	Kind        string        `json:"kind,omitempty"`
+	Ingredients []*RecipeTree `json:"ingredients,omitempty"`
+}
+
+// Tree returns the tree of what is needed to make count of the item, down to
+// raw and bought items.
+func (rs *Recipes) Tree(item string, count float64) *RecipeTree {
+	t := &RecipeTree{Item: item, Count: count, Recipe: rs.recipes[item]}
+	if t.Recipe == nil {
+		return t
+	}
+	t.Kind = t.Recipe.Kind
+	crafts := count / t.Recipe.Makes
+	for _, in := range t.Recipe.Ingredients {
+		t.Ingredients = append(t.Ingredients, rs.Tree(in.Item, crafts*in.Count))
+	}
+	return t
+}
+
+// Raw returns the total count of each item at the leaves of the tree, which
+// are the raw and bought items.
+func (t *RecipeTree) Raw() map[string]float64 {
+	raw := map[string]float64{}
+	var walk func(t *RecipeTree)
+	walk = func(t *RecipeTree) {
+		if t.Recipe == nil {
+			raw[t.Item] += t.Count
+			return
+		}
+		for _, in := range t.Ingredients {
+			walk(in)
+		}
+	}
+	walk(t)
+	return raw
+}
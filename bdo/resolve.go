@@ -0,0 +1,182 @@
+package bdo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NotFoundError is returned by Resolve when a name does not match exactly one
+// node.
+type NotFoundError struct {
+	Name string
+	// Matches are the nodes the name matched equally well, if more than one
+	// did.
+	Matches []string
+	// Suggestions are the closest node names by edit distance, if nothing
+	// matched.
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	if len(e.Matches) > resolveMatchesShown {
+		return fmt.Sprintf("%q could be any of %s, and %d more.", e.Name, quotedList(e.Matches[:resolveMatchesShown], ","), len(e.Matches)-resolveMatchesShown)
+	}
+	if len(e.Matches) > 0 {
+		return fmt.Sprintf("%q could be any of %s.", e.Name, quotedList(e.Matches, "and"))
+	}
+	if len(e.Suggestions) > 0 {
+		return fmt.Sprintf("Could not find node %q; did you mean %s?", e.Name, quotedList(e.Suggestions, "or"))
+	}
+	return fmt.Sprintf("Could not find node %q.", e.Name)
+}
+
+// quotedList returns the names quoted and separated by commas, with the
+// conjunction before the last one; a conjunction of "," just uses commas.
+func quotedList(names []string, conjunction string) string {
+	var s string
+	for i, n := range names {
+		switch {
+		case i == 0:
+		case conjunction == ",":
+			s += ", "
+		case i == len(names)-1 && len(names) == 2:
+			s += " " + conjunction + " "
+		case i == len(names)-1:
+			s += ", " + conjunction + " "
+		default:
+			s += ", "
+		}
+		s += fmt.Sprintf("%q", n)
+	}
+	return s
+}
+
+const (
+	// resolveSuggestions is how many names a NotFoundError suggests.
+	resolveSuggestions = 3
+	// resolveMatchesShown is how many of the Matches a NotFoundError lists.
+	resolveMatchesShown = 10
+)
+
+// Resolve returns the node name refers to. The name may be the node's full
+// name, ignoring case; or the start of exactly one node's name; or an
+// abbreviation of exactly one node's name, either the starts of some of its
+// words in order, such as "pil hum" for "Pilgrim's Sanctum: Humility", or
+// its initials, such as "psh". A parent node is chosen over its production
+// nodes when both match. Otherwise a *NotFoundError is returned.
+func (g *Graph) Resolve(name string) (*Node, error) {
+	if n := g.Find(name); n != nil {
+		return n, nil
+	}
+	nameL := strings.ToLower(strings.TrimSpace(name))
+	if nameL == "" {
+		return nil, &NotFoundError{Name: name}
+	}
+	var prefixed []string
+	var abbreviated []string
+	for _, n := range g.Nodes() {
+		nL := strings.ToLower(n.Name)
+		if strings.HasPrefix(nL, nameL) {
+			prefixed = append(prefixed, n.Name)
+		} else if abbreviates(nameL, nL) {
+			abbreviated = append(abbreviated, n.Name)
+		}
+	}
+	for _, matches := range [][]string{prefixed, abbreviated} {
+		if len(matches) == 0 {
+			continue
+		}
+		// A parent node matches along with its production nodes, such as
+		// "Toscani Farm" and "Toscani Farm: A", so it wins over them.
+		parent := matches[0]
+		for _, m := range matches[1:] {
+			if !strings.HasPrefix(m, parent+": ") {
+				parent = ""
+				break
+			}
+		}
+		if parent != "" {
+			return g.nodes[parent], nil
+		}
+		return nil, &NotFoundError{Name: name, Matches: matches}
+	}
+	type distance struct {
+		name     string
+		distance int
+	}
+	var ds []distance
+	nameR := []rune(nameL)
+	for _, n := range g.Nodes() {
+		// Compare with the start of longer names too, so a misspelled
+		// start of a name finds that name.
+		nR := []rune(strings.ToLower(n.Name))
+		d := editDistance(nameR, nR)
+		if len(nR) > len(nameR) {
+			if d2 := editDistance(nameR, nR[:len(nameR)]); d2 < d {
+				d = d2
+			}
+		}
+		ds = append(ds, distance{n.Name, d})
+	}
+	sort.SliceStable(ds, func(i, j int) bool { return ds[i].distance < ds[j].distance })
+	err := &NotFoundError{Name: name}
+	for i := 0; i < len(ds) && i < resolveSuggestions; i++ {
+		err.Suggestions = append(err.Suggestions, ds[i].name)
+	}
+	return nil, err
+}
+
+// abbreviates returns true if each word of abbreviation starts a word of name,
+// in order, or if abbreviation is the initials of name. Both should already be
+// lower case.
+func abbreviates(abbreviation string, name string) bool {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == ' ' || r == ':' })
+	var initials string
+	for _, w := range words {
+		initials += w[:1]
+	}
+	if abbreviation == initials {
+		return true
+	}
+	parts := strings.Fields(abbreviation)
+	if len(parts) < 2 {
+		return false
+	}
+	for _, w := range words {
+		if strings.HasPrefix(w, parts[0]) {
+			parts = parts[1:]
+			if len(parts) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(ra []rune, rb []rune) int {
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,31 @@
+package bdo
+
+import "testing"
+
+func TestResolve(t *testing.T) {
+	g := testGraph(t)
+	for name, want := range map[string]string{
+		"toscani":         "Toscani Farm",
+		"toscani farm: a": "Toscani Farm: A",
+		"psh":             "Pilgrim's Sanctum: Humility",
+		"pil hum":         "Pilgrim's Sanctum: Humility",
+		"olvia":           "Olvia",
+	} {
+		n, err := g.Resolve(name)
+		if err != nil {
+			t.Errorf("%q: %s", name, err)
+		} else if n.Name != want {
+			t.Errorf("%q resolved to %q, not %q", name, n.Name, want)
+		}
+	}
+	// A shorter name only wins over its own production nodes, not over
+	// other nodes it starts.
+	for _, name := range []string{"kep", "cron cas", "olv"} {
+		n, err := g.Resolve(name)
+		if err == nil {
+			t.Errorf("%q resolved to %q rather than being ambiguous", name, n.Name)
+		} else if len(err.(*NotFoundError).Matches) < 2 {
+			t.Errorf("%q: %s", name, err)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package bdo
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// steinerExactMax is the most targets ConnectAll will solve exactly; the exact
+// search grows with 3^targets so larger sets use the shortest path heuristic.
+const steinerExactMax = 8
+
+// ConnectAll returns the contribution points needed to connect all the
+// targets to the owned network at once, and the unowned nodes to buy in an
+// order where each is next to something already owned. The cost is -1 if the
+// targets cannot all be connected.
+func (g *Graph) ConnectAll(targets []string) (int, []string) {
+	var need []string
+	for _, t := range targets {
+		if !g.nodes[t].Owned {
+			need = append(need, t)
+		}
+	}
+	if len(need) == 0 {
+		return 0, nil
+	}
+	var chosen map[string]struct{}
+	if len(need) <= steinerExactMax {
+		chosen = g.steinerExact(need)
+	} else {
+		chosen = g.steinerHeuristic(need)
+	}
+	if chosen == nil {
+		return -1, nil
+	}
+	// Buy outward from the owned network so every purchase is connected.
+	var buy []string
+	cost := 0
+	reached := map[string]struct{}{}
+	for n := range g.nodes {
+		if g.nodes[n].Owned {
+			reached[n] = struct{}{}
+		}
+	}
+	for len(buy) < len(chosen) {
+		var next []string
+		for n := range chosen {
+			if _, ok := reached[n]; ok {
+				continue
+			}
+			for n2 := range g.connections[n] {
+				if _, ok := reached[n2]; ok {
+					next = append(next, n)
+					break
+				}
+			}
+		}
+		if len(next) == 0 {
+			return -1, nil
+		}
+		sort.Strings(next)
+		for _, n := range next {
+			reached[n] = struct{}{}
+			buy = append(buy, n)
+			cost += g.nodes[n].ContributionPoints
+		}
+	}
+	return cost, buy
+}
+
+// steinerExact solves the node weighted Steiner tree with the Dreyfus-Wagner
+// dynamic program. The owned network is merged into one extra terminal, a
+// virtual root joined at no cost to every owned node, so each target may
+// connect to whichever part of the network is cheapest. dp[mask][v] is the
+// cheapest tree containing v that connects the terminals in mask. It returns
+// the unowned nodes of the tree, or nil if there is none.
+func (g *Graph) steinerExact(need []string) map[string]struct{} {
+	names := make([]string, 0, len(g.nodes))
+	for n := range g.nodes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	index := make(map[string]int, len(names))
+	for i, n := range names {
+		index[n] = i
+	}
+	// The root is the last vertex, named "" as no node can be.
+	root := len(names)
+	names = append(names, "")
+	index[""] = root
+	weight := make([]int, len(names))
+	adjacent := make([][]int, len(names))
+	for i, n := range names[:root] {
+		if !g.nodes[n].Owned {
+			weight[i] = g.nodes[n].ContributionPoints
+		} else {
+			adjacent[i] = append(adjacent[i], root)
+			adjacent[root] = append(adjacent[root], i)
+		}
+		for n2 := range g.connections[n] {
+			if _, ok := g.nodes[n2]; ok {
+				adjacent[i] = append(adjacent[i], index[n2])
+			}
+		}
+	}
+	type step struct {
+		split int
+		from  int
+	}
+	infinity := int(^uint(0) >> 1)
+	rootBit := 1 << uint(len(need))
+	full := rootBit<<1 - 1
+	dp := make([][]int, full+1)
+	back := make([][]step, full+1)
+	for mask := 1; mask <= full; mask++ {
+		dp[mask] = make([]int, len(names))
+		back[mask] = make([]step, len(names))
+		for v := range names {
+			dp[mask][v] = infinity
+			back[mask][v] = step{from: -1}
+		}
+	}
+	for i, t := range need {
+		dp[1<<uint(i)][index[t]] = weight[index[t]]
+	}
+	dp[rootBit][root] = 0
+	for mask := 1; mask <= full; mask++ {
+		cur := dp[mask]
+		for sub := (mask - 1) & mask; sub > 0; sub = (sub - 1) & mask {
+			if sub < mask^sub {
+				continue
+			}
+			for v := range names {
+				a, b := dp[sub][v], dp[mask^sub][v]
+				if a == infinity || b == infinity {
+					continue
+				}
+				if c := a + b - weight[v]; c < cur[v] {
+					cur[v] = c
+					back[mask][v] = step{split: sub, from: -1}
+				}
+			}
+		}
+		q := &costQueue{}
+		for v, c := range cur {
+			if c != infinity {
+				heap.Push(q, &costName{cost: c, name: names[v]})
+			}
+		}
+		for q.Len() > 0 {
+			cn := heap.Pop(q).(*costName)
+			v := index[cn.name]
+			if cn.cost > cur[v] {
+				continue
+			}
+			for _, u := range adjacent[v] {
+				if c := cur[v] + weight[u]; c < cur[u] {
+					cur[u] = c
+					back[mask][u] = step{from: v}
+					heap.Push(q, &costName{cost: c, name: names[u]})
+				}
+			}
+		}
+	}
+	if dp[full][root] == infinity {
+		return nil
+	}
+	chosen := map[string]struct{}{}
+	var collect func(mask int, v int)
+	collect = func(mask int, v int) {
+		if v != root && !g.nodes[names[v]].Owned {
+			chosen[names[v]] = struct{}{}
+		}
+		s := back[mask][v]
+		if s.split != 0 {
+			collect(s.split, v)
+			collect(mask^s.split, v)
+		} else if s.from != -1 {
+			collect(mask, s.from)
+		}
+	}
+	collect(full, root)
+	return chosen
+}
+
+// steinerHeuristic repeatedly connects whichever remaining target is
+// cheapest to reach from the network built so far. It returns the unowned
+// nodes it used, or nil if some target cannot be reached.
+func (g *Graph) steinerHeuristic(need []string) map[string]struct{} {
+	g = g.Clone()
+	chosen := map[string]struct{}{}
+	remaining := append([]string(nil), need...)
+	for len(remaining) > 0 {
+		bestI := -1
+		bestCost := 0
+		var bestPath []string
+		for i, t := range remaining {
+			if g.nodes[t].Owned {
+				bestI, bestCost, bestPath = i, 0, nil
+				break
+			}
+			cost, pths := g.BestPaths(t, "")
+			if len(pths) > 0 && (bestI == -1 || cost < bestCost) {
+				bestI, bestCost, bestPath = i, cost, pths[0]
+			}
+		}
+		if bestI == -1 {
+			return nil
+		}
+		for _, n := range bestPath {
+			if !g.nodes[n].Owned {
+				g.nodes[n].Owned = true
+				chosen[n] = struct{}{}
+			}
+		}
+		remaining = append(remaining[:bestI], remaining[bestI+1:]...)
+	}
+	return chosen
+}
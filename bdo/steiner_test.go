@@ -0,0 +1,63 @@
+package bdo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestConnectAllSeparateTowns(t *testing.T) {
+	g := testGraph(t)
+	cost, buy := g.ConnectAll([]string{"Keplan Quarry: A", "Glish Swamp: A"})
+	if cost != 4 {
+		t.Errorf("cost was %d buying %v, not 4", cost, buy)
+	}
+}
+
+// TestConnectAllNoMoreThanPaths checks ConnectAll never costs more than
+// buying the union of each target's own best path.
+func TestConnectAllNoMoreThanPaths(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, regions := range [][]string{nil, {"Balenos"}, {"Serendia", "Calpheon"}} {
+		g := testGraph(t, regions...)
+		var candidates []string
+		for _, n := range g.Nodes() {
+			if !n.Owned && len(n.Produces) > 0 {
+				if _, pths := g.BestPaths(n.Name, ""); len(pths) > 0 {
+					candidates = append(candidates, n.Name)
+				}
+			}
+		}
+		for i := 0; i < 20; i++ {
+			var targets []string
+			for j := 2 + rnd.Intn(3); j > 0; j-- {
+				targets = append(targets, candidates[rnd.Intn(len(candidates))])
+			}
+			union := map[string]struct{}{}
+			for _, target := range targets {
+				_, pths := g.BestPaths(target, "")
+				for _, n := range pths[0] {
+					if !g.nodes[n].Owned {
+						union[n] = struct{}{}
+					}
+				}
+			}
+			unionCost := 0
+			for n := range union {
+				unionCost += g.nodes[n].ContributionPoints
+			}
+			cost, buy := g.ConnectAll(targets)
+			if cost > unionCost {
+				t.Errorf("%v cost %d buying %v, more than %d for their own paths", targets, cost, buy, unionCost)
+			}
+			bought := map[string]struct{}{}
+			for _, n := range buy {
+				bought[n] = struct{}{}
+			}
+			for _, target := range targets {
+				if _, ok := bought[target]; !ok {
+					t.Errorf("%v did not buy %s", targets, target)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package bdo
+
+import (
+	"fmt"
+	"math"
+)
+
+// Position is where a node is on the world map, in whatever units the node
+// data uses. The built in node data has no positions, as no sourced
+// coordinates were available.
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// TradeBonus is how the distance bonus for trading goods grows: PerDistance
+// percent for each unit of straight-line distance between the towns, up to
+// Max percent. The built in node data has none, as no sourced figures were
+// available.
+type TradeBonus struct {
+	PerDistance float64 `json:"perDistance"`
+	Max         float64 `json:"max"`
+}
+
+// SetTradeBonus sets the TradeBonus TradeRoute uses, or nil if not known.
+func (g *Graph) SetTradeBonus(tb *TradeBonus) {
+	g.tradeBonus = tb
+}
+
+// TradeRoute is the way between two towns for trading goods.
+type TradeRoute struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Hops is the fewest connections between the towns.
+	Hops int `json:"hops"`
+	// Route is the cheapest path to connect the towns, as BestPaths finds,
+	// and Cost is the contribution points of its unowned nodes.
+	Route []string `json:"route"`
+	Cost  int      `json:"cost"`
+	// Distance is nil if either town's position is not known, and Bonus, the
+	// distance bonus percentage, is also nil if the TradeBonus is not known.
+	Distance *float64 `json:"distance,omitempty"`
+	Bonus    *float64 `json:"bonus,omitempty"`
+	// TradeNodes are the trade nodes on the Route, which must all be owned
+	// for the trade to count as connected; Unowned are those not owned yet.
+	TradeNodes []string `json:"tradeNodes"`
+	Unowned    []string `json:"unowned"`
+}
+
+// TradeRoute returns the route between the named towns, or an error if either
+// is not a town or there is no route.
+func (g *Graph) TradeRoute(from string, to string) (*TradeRoute, error) {
+	for _, name := range []string{from, to} {
+		if n := g.nodes[name]; n == nil || n.Kind != KindTown {
+			return nil, fmt.Errorf("%s is not a town.", name)
+		}
+	}
+	if from == to {
+		return nil, fmt.Errorf("%s is both ends of the route.", from)
+	}
+	hops, ok := g.hops(from, false)[to]
+	if !ok {
+		return nil, fmt.Errorf("There is no route from %s to %s.", from, to)
+	}
+	cost, pths := g.BestPaths(from, to)
+	if len(pths) == 0 {
+		return nil, fmt.Errorf("There is no route from %s to %s.", from, to)
+	}
+	tr := &TradeRoute{From: from, To: to, Hops: hops, Route: pths[0], Cost: cost, TradeNodes: []string{}, Unowned: []string{}}
+	if a, b := g.nodes[from].Position, g.nodes[to].Position; a != nil && b != nil {
+		distance := math.Hypot(a.X-b.X, a.Y-b.Y)
+		tr.Distance = &distance
+		if tb := g.tradeBonus; tb != nil {
+			bonus := math.Min(distance*tb.PerDistance, tb.Max)
+			tr.Bonus = &bonus
+		}
+	}
+	for _, name := range tr.Route {
+		if n := g.nodes[name]; n.Kind == KindTrade {
+			tr.TradeNodes = append(tr.TradeNodes, name)
+			if !n.Owned {
+				tr.Unowned = append(tr.Unowned, name)
+			}
+		}
+	}
+	return tr, nil
+}
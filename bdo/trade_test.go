@@ -0,0 +1,47 @@
+package bdo
+
+import "testing"
+
+func TestTradeRouteOwnedNetwork(t *testing.T) {
+	// A large owned network made the depth first BestPaths take too long to
+	// finish.
+	g := testGraph(t, "Balenos", "Serendia", "Calpheon")
+	tr, err := g.TradeRoute("Velia", "Heidel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Cost != 0 {
+		t.Errorf("cost was %d, not 0", tr.Cost)
+	}
+	if len(tr.Route)-1 != tr.Hops {
+		t.Errorf("route %v is not %d connections", tr.Route, tr.Hops)
+	}
+	if tr.Distance != nil || tr.Bonus != nil {
+		t.Errorf("distance %v and bonus %v without positions", tr.Distance, tr.Bonus)
+	}
+}
+
+func TestTradeRouteBonus(t *testing.T) {
+	g := testGraph(t)
+	g.nodes["Velia"].Position = &Position{X: 0, Y: 0}
+	g.nodes["Heidel"].Position = &Position{X: 30, Y: 40}
+	tr, err := g.TradeRoute("Velia", "Heidel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Distance == nil || *tr.Distance != 50 {
+		t.Errorf("distance was %v, not 50", tr.Distance)
+	}
+	if tr.Bonus != nil {
+		t.Errorf("bonus was %v without a TradeBonus", *tr.Bonus)
+	}
+	for max, want := range map[float64]float64{100: 25, 20: 20} {
+		g.SetTradeBonus(&TradeBonus{PerDistance: 0.5, Max: max})
+		if tr, err = g.TradeRoute("Velia", "Heidel"); err != nil {
+			t.Fatal(err)
+		}
+		if tr.Bonus == nil || *tr.Bonus != want {
+			t.Errorf("bonus up to %v was %v, not %v", max, tr.Bonus, want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package bdo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Worker is the type and stats of a worker assigned to a production node.
+type Worker struct {
+	Type          string  `json:"type"`
+	WorkSpeed     float64 `json:"workSpeed"`
+	MovementSpeed float64 `json:"movementSpeed"`
+	Stamina       int     `json:"stamina"`
+}
+
+// WorkerTypes are rough stats for a new worker of each type, used when a
+// worker's own stats are not given.
+var WorkerTypes = map[string]Worker{
+	"goblin": {Type: "goblin", WorkSpeed: 55, MovementSpeed: 7, Stamina: 7},
+	"human":  {Type: "human", WorkSpeed: 70, MovementSpeed: 4.5, Stamina: 10},
+	"giant":  {Type: "giant", WorkSpeed: 45, MovementSpeed: 3.5, Stamina: 15},
+}
+
+// DefaultWorkerType is assumed for estimates when a node's worker type is not
+// known.
+const DefaultWorkerType = "human"
+
+// ParseWorker parses "<type> [work speed] [movement speed] [stamina]", with
+// any stats not given taken from WorkerTypes.
+func ParseWorker(s string) (*Worker, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 4 {
+		return nil, fmt.Errorf("Expected a worker as <type> [work speed] [movement speed] [stamina], not %q.", s)
+	}
+	w, ok := WorkerTypes[strings.ToLower(fields[0])]
+	if !ok {
+		var types []string
+		for t := range WorkerTypes {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		return nil, fmt.Errorf("Unknown worker type %q; it should be one of %s.", fields[0], strings.Join(types, ", "))
+	}
+	for i, f := range fields[1:] {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("Invalid worker stat %q in %q.", f, s)
+		}
+		switch i {
+		case 0:
+			w.WorkSpeed = v
+		case 1:
+			w.MovementSpeed = v
+		case 2:
+			w.Stamina = int(v)
+		}
+	}
+	return &w, nil
+}
+
+// String returns the worker in the form ParseWorker reads.
+func (w *Worker) String() string {
+	return fmt.Sprintf("%s %g %g %d", w.Type, w.WorkSpeed, w.MovementSpeed, w.Stamina)
+}
+
+// Estimate is the expected output of a worker on a production node.
+type Estimate struct {
+	Node   string `json:"node"`
+	Town   string `json:"town"`
+	Worker Worker `json:"worker"`
+	// Assumed is true if the worker's type was not known and the
+	// DefaultWorkerType was used.
+	Assumed       bool    `json:"assumed,omitempty"`
+	Distance      float64 `json:"distance"`
+	CycleMinutes  float64 `json:"cycleMinutes"`
+	CyclesPerHour float64 `json:"cyclesPerHour"`
+	ItemsPerHour  float64 `json:"itemsPerHour"`
+	// StaminaHours is how long the worker can keep working before it needs
+	// to be fed.
+	StaminaHours float64 `json:"staminaHours"`
+}
+
+// Estimate returns the expected output of the named production node worked
+// by a worker from town, using the node's Worker if it has one. It returns
+// nil if the node's workload or distance is not known.
+//
+// Work takes 10 minutes for each full or partial multiple of the worker's
+// work speed in the node's workload, plus the travel there and back. The
+// distance in the node data is from its closest worker town; from other
+// towns it is scaled by the number of connections travelled. Each cycle is
+// counted as producing one item.
+func (g *Graph) Estimate(name string, town string) *Estimate {
+	n := g.nodes[name]
+	if n == nil || n.Workload <= 0 || n.Distance <= 0 {
+		return nil
+	}
+	e := &Estimate{Node: n.Name, Town: town, Distance: n.Distance}
+	if n.Worker != nil {
+		e.Worker = *n.Worker
+	} else {
+		e.Worker = WorkerTypes[DefaultWorkerType]
+		e.Assumed = true
+	}
+	if town != n.ClosestWorker && n.ClosestWorker != "" {
+		from := g.hops(town, false)[n.Name]
+		closest := g.hops(n.ClosestWorker, false)[n.Name]
+		if from > 0 && closest > 0 {
+			e.Distance = n.Distance * float64(from) / float64(closest)
+		}
+	}
+	work := math.Ceil(float64(n.Workload)/e.Worker.WorkSpeed) * 10
+	travel := 2 * e.Distance / e.Worker.MovementSpeed / 60
+	e.CycleMinutes = work + travel
+	e.CyclesPerHour = 60 / e.CycleMinutes
+	e.ItemsPerHour = e.CyclesPerHour
+	e.StaminaHours = float64(e.Worker.Stamina) * e.CycleMinutes / 60
+	return e
+}
@@ -0,0 +1,26 @@
+package bdo
+
+import "testing"
+
+func TestEstimate(t *testing.T) {
+	g := testGraph(t)
+	name := "Bartali Farm: A"
+	if g.Estimate(name, "Velia") != nil {
+		t.Errorf("%s was estimated without a workload or distance", name)
+	}
+	n := g.Node(name)
+	n.Workload = 100
+	n.Distance = 1800
+	n.Worker = &Worker{Type: "human", WorkSpeed: 50, MovementSpeed: 5, Stamina: 10}
+	e := g.Estimate(name, n.ClosestWorker)
+	if e == nil {
+		t.Fatalf("%s was not estimated", name)
+	}
+	// 2 work steps of 10 minutes, and 1800 there and back at 5 a second.
+	if e.CycleMinutes != 32 {
+		t.Errorf("cycle took %v minutes, not 32", e.CycleMinutes)
+	}
+	if perDay, estimated := g.PerDay(name, n.ClosestWorker); !estimated || perDay != 24*60/32.0 {
+		t.Errorf("per day was %v, %v", perDay, estimated)
+	}
+}
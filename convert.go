@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+var convertFormats = []string{"table", "csv", "tsv", "json", "markdown"}
+
+func convert(args []string) {
+	if len(args) < 2 || len(args) > 3 {
+		help(fmt.Sprintf("convert needs a <from> and <to> format, each one of %s, and optionally a [file]", strings.Join(convertFormats, ", ")), 1)
+	}
+	from := convertFormat(args[0])
+	to := convertFormat(args[1])
+	var r io.Reader = os.Stdin
+	if len(args) == 3 && args[2] != "-" {
+		f, err := os.Open(args[2])
+		errnil(err)
+		defer f.Close()
+		r = f
+	}
+	var header []string
+	var data [][]string
+	var err error
+	switch from {
+	case "table":
+		header, data = tableReadFrom(r)
+	case "csv", "tsv":
+		header, data, err = convertReadCSV(r, from == "tsv")
+	case "json":
+		header, data, err = convertReadJSON(r)
+	case "markdown":
+		header, data, err = convertReadMarkdown(r)
+	}
+	errnil(err)
+	w := bufio.NewWriter(os.Stdout)
+	switch to {
+	case "table":
+		_, err = w.WriteString(alignTable(append([][]string{header, nil}, data...)))
+	case "csv", "tsv":
+		c := csv.NewWriter(w)
+		if to == "tsv" {
+			c.Comma = '\t'
+		}
+		err = c.WriteAll(append([][]string{header}, data...))
+	case "json":
+		err = convertWriteJSON(w, header, data)
+	case "markdown":
+		convertWriteMarkdown(w, header, data)
+	}
+	errnil(err)
+	errnil(w.Flush())
+}
+
+// convertFormat returns the format named, allowing md for markdown, or exits
+// with an error.
+func convertFormat(name string) string {
+	name = strings.ToLower(name)
+	if name == "md" {
+		return "markdown"
+	}
+	for _, f := range convertFormats {
+		if f == name {
+			return f
+		}
+	}
+	help(fmt.Sprintf("Unknown format %q; it should be one of %s.", name, strings.Join(convertFormats, ", ")), 1)
+	return ""
+}
+
+func convertReadCSV(r io.Reader, tabs bool) ([]string, [][]string, error) {
+	c := csv.NewReader(r)
+	if tabs {
+		c.Comma = '\t'
+		c.LazyQuotes = true
+	}
+	rows, err := c.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("no data")
+	}
+	return rows[0], rows[1:], nil
+}
+
+// convertReadJSON reads an array of objects, with the columns being the keys
+// in the order they first appear. Values that are not strings are written as
+// JSON, except null which is empty.
+func convertReadJSON(r io.Reader) ([]string, [][]string, error) {
+	var objects []map[string]json.RawMessage
+	var header []string
+	columns := map[string]int{}
+	d := json.NewDecoder(r)
+	if t, err := d.Token(); err != nil || t != json.Delim('[') {
+		return nil, nil, fmt.Errorf("expected a JSON array of objects")
+	}
+	for d.More() {
+		if t, err := d.Token(); err != nil || t != json.Delim('{') {
+			return nil, nil, fmt.Errorf("expected a JSON array of objects")
+		}
+		object := map[string]json.RawMessage{}
+		for d.More() {
+			t, err := d.Token()
+			if err != nil {
+				return nil, nil, err
+			}
+			key := t.(string)
+			var value json.RawMessage
+			if err := d.Decode(&value); err != nil {
+				return nil, nil, err
+			}
+			if _, ok := columns[key]; !ok {
+				columns[key] = len(header)
+				header = append(header, key)
+			}
+			object[key] = value
+		}
+		if _, err := d.Token(); err != nil {
+			return nil, nil, err
+		}
+		objects = append(objects, object)
+	}
+	if _, err := d.Token(); err != nil {
+		return nil, nil, err
+	}
+	data := make([][]string, len(objects))
+	for i, object := range objects {
+		data[i] = make([]string, len(header))
+		for key, value := range object {
+			var s string
+			if err := json.Unmarshal(value, &s); err == nil {
+				data[i][columns[key]] = s
+			} else if string(value) != "null" {
+				var b bytes.Buffer
+				errnil(json.Compact(&b, value))
+				data[i][columns[key]] = b.String()
+			}
+		}
+	}
+	return header, data, nil
+}
+
+// convertWriteJSON writes an array of objects, keeping the column order for
+// the keys. All values are written as strings.
+func convertWriteJSON(w io.Writer, header []string, data [][]string) error {
+	var b bytes.Buffer
+	b.WriteString("[")
+	for i, row := range data {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n    {")
+		for j, column := range header {
+			if j > 0 {
+				b.WriteString(",")
+			}
+			k, _ := json.Marshal(column)
+			v, _ := json.Marshal(row[j])
+			fmt.Fprintf(&b, "\n        %s: %s", k, v)
+		}
+		b.WriteString("\n    }")
+	}
+	if len(data) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// convertReadMarkdown reads a Markdown table: a header line, a line of
+// dashes and colons, and the data lines, with the outer pipes optional and
+// \| for a pipe within a cell. Lines before the table are skipped and it ends
+// at the first line without a pipe after it starts.
+func convertReadMarkdown(r io.Reader) ([]string, [][]string, error) {
+	var rows [][]string
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.Contains(line, "|") {
+			if len(rows) > 0 {
+				break
+			}
+			continue
+		}
+		cells := markdownCells(line)
+		if len(rows) == 1 {
+			for _, c := range cells {
+				if strings.Trim(c, ":-") != "" || !strings.Contains(c, "-") {
+					return nil, nil, fmt.Errorf("line number %d should separate the header from the data with dashes", lineNumber)
+				}
+			}
+			rows = append(rows, nil)
+			continue
+		}
+		if len(rows) > 0 && len(cells) != len(rows[0]) {
+			return nil, nil, fmt.Errorf("line number %d has incorrect number of columns; had %d and expected %d", lineNumber, len(cells), len(rows[0]))
+		}
+		rows = append(rows, cells)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(rows) < 2 {
+		return nil, nil, fmt.Errorf("no data")
+	}
+	return rows[0], rows[2:], nil
+}
+
+func markdownCells(line string) []string {
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func convertWriteMarkdown(w io.Writer, header []string, data [][]string) {
+	escaped := func(row []string) []string {
+		e := make([]string, len(row))
+		for i, c := range row {
+			e[i] = strings.ReplaceAll(c, "|", `\|`)
+		}
+		return e
+	}
+	rows := [][]string{escaped(header)}
+	for _, row := range data {
+		rows = append(rows, escaped(row))
+	}
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, c := range row {
+			if n := utf8.RuneCountInString(c); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	for i := range widths {
+		if widths[i] < 3 {
+			widths[i] = 3
+		}
+	}
+	line := func(row []string) {
+		fmt.Fprint(w, "|")
+		for i, c := range row {
+			fmt.Fprintf(w, " %s%s |", c, strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c)))
+		}
+		fmt.Fprintln(w)
+	}
+	line(rows[0])
+	dashes := make([]string, len(widths))
+	for i, n := range widths {
+		dashes[i] = strings.Repeat("-", n)
+	}
+	line(dashes)
+	for _, row := range rows[1:] {
+		line(row)
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
-
-	"github.com/gholt/brimtext"
 )
 
 func csvToTable(args []string) {
@@ -17,5 +15,5 @@ func csvToTable(args []string) {
 	data = append(data, nil)
 	copy(data[2:], data[1:])
 	data[1] = nil
-	fmt.Print(brimtext.Align(data, brimtext.NewSimpleAlignOptions()))
+	printTable(data)
 }
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gholt/bdot/bdo"
+)
+
+func itemsCommand(g *bdo.Graph, args []string) {
+	var cmd string
+	if len(args) > 0 {
+		cmd = args[0]
+		args = args[1:]
+	}
+	switch cmd {
+	case "list":
+		if len(args) > 0 {
+			help("items list takes no arguments", 1)
+		}
+		itemsList(g)
+	case "where":
+		if len(args) < 1 {
+			help("items where needs an <item>", 1)
+		}
+		item, err := g.ResolveItem(strings.Join(args, " "))
+		if err != nil {
+			help(err.Error(), 1)
+		}
+		itemsWhere(g, item)
+	default:
+		help(fmt.Sprintf("Unknown items command %q.", cmd), 1)
+	}
+}
+
+func itemsList(g *bdo.Graph) {
+	type itemJSON struct {
+		*bdo.Item
+		Owned int `json:"owned"`
+	}
+	items := []itemJSON{}
+	for _, item := range g.Items() {
+		owned := 0
+		for _, n := range item.Nodes {
+			if g.Node(n).Owned {
+				owned++
+			}
+		}
+		items = append(items, itemJSON{item, owned})
+	}
+	if outputFormat == "json" {
+		printJSON(items)
+		return
+	}
+	report := [][]string{{"Item", "Nodes", "Owned"}, nil}
+	for _, item := range items {
+		report = append(report, []string{item.Name, strconv.Itoa(len(item.Nodes)), strconv.Itoa(item.Owned)})
+	}
+	printTable(report)
+}
+
+// itemsWhere shows the nodes producing the item, cheapest to connect first.
+// Nodes that cannot be connected to the network are last, with no cost.
+func itemsWhere(g *bdo.Graph, item *bdo.Item) {
+	var cns costNodes
+	for _, name := range item.Nodes {
+		n := g.Node(name)
+		cost := 0
+		if !n.Owned {
+			var paths [][]string
+			cost, paths = g.BestPaths(n.Name, "")
+			if len(paths) == 0 {
+				cost = -1
+			}
+		}
+		cns = append(cns, &costNode{cost: cost, node: n})
+	}
+	sort.Sort(cns)
+	sort.SliceStable(cns, func(i, j int) bool { return cns[i].cost >= 0 && cns[j].cost < 0 })
+	if outputFormat == "json" {
+		type whereJSON struct {
+			Node          string `json:"node"`
+			ClosestWorker string `json:"closestWorker,omitempty"`
+			Owned         bool   `json:"owned"`
+			Cost          *int   `json:"cost"`
+		}
+		results := []whereJSON{}
+		for _, cn := range cns {
+			w := whereJSON{Node: cn.node.Name, ClosestWorker: cn.node.ClosestWorker, Owned: cn.node.Owned}
+			if cn.cost >= 0 {
+				w.Cost = &cn.cost
+			}
+			results = append(results, w)
+		}
+		printJSON(results)
+		return
+	}
+	report := [][]string{{"Node", "Closest Worker", "Owned", "CP"}, nil}
+	for _, cn := range cns {
+		owned := "no"
+		if cn.node.Owned {
+			owned = "yes"
+		}
+		cost := ""
+		if cn.cost >= 0 {
+			cost = strconv.Itoa(cn.cost)
+		}
+		report = append(report, []string{cn.node.Name, cn.node.ClosestWorker, owned, cost})
+	}
+	printTable(report)
+}
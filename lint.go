@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gholt/bdot/bdo"
+)
+
+// lint checks the node data that would be loaded and exits nonzero if it has
+// any problems.
+func lint(args []string) {
+	if len(args) != 0 {
+		help("lint takes no parameters", 1)
+	}
+	var report *bdo.LintReport
+	var err error
+	if nodesFile := nodesFileName(); nodesFile == "" {
+		report, err = bdo.LintDefault()
+	} else {
+		report, err = bdo.LintFile(nodesFile)
+	}
+	errnil(err)
+	switch outputFormat {
+	case "json":
+		printJSON(report)
+	case "csv":
+		rows := [][]string{{"Kind", "Message"}}
+		for _, p := range report.Problems {
+			rows = append(rows, []string{p.Kind, p.Message})
+		}
+		printCSV(rows)
+	default:
+		for _, p := range report.Problems {
+			fmt.Println(p.Message)
+		}
+		if report.MissingConnections > 0 {
+			fmt.Printf("%d known connections are to nodes not entered yet.\n", report.MissingConnections)
+		}
+		if report.MissingWorkloads > 0 {
+			fmt.Printf("%d production nodes have no workload or distance to estimate their output.\n", report.MissingWorkloads)
+		}
+		fmt.Printf("%d problems found.\n", len(report.Problems))
+	}
+	if len(report.Problems) > 0 {
+		os.Exit(1)
+	}
+}
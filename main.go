@@ -3,46 +3,230 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 )
 
-func init() {
-	nodesinit()
-}
+// These are set by the options given before the command.
+var (
+	nodesFile   string
+	recipesFile string
+	lenient     bool
+)
 
 func help(msg string, exitCode int) {
-	fmt.Printf(`%s <command> [args]
+	if inShell {
+		panic(shellError(msg))
+	}
+	usage()
+	if msg != "" {
+		fmt.Println("")
+		fmt.Fprintln(os.Stderr, msg)
+	}
+	os.Exit(exitCode)
+}
+
+func usage() {
+	fmt.Printf(`%s [--nodes <file>] [--recipes <file>] [--format <format>] [--lenient]
+    <command> [args]
 
 This tool was written to serve as a personal Black Desert Database. It is
 missing a ton of information, likely has some incorrect information, and
 probably is only useful to me.
 
-nodes [worker city]
-    Shows information about your node network. You can provide a [worker city]
-    to just display what is being produced by workers from that city.
+The --format option can be text, the default, or json or csv to write the
+results of the nodes, items, recipes, plan, trade, table, csv, and lint
+commands in a form easier for other programs to read.
+
+nodes [--region <region>] [worker city]
+    Shows information about your node network, with subtotals for each
+    region. With --region only the nodes in that region, such as Balenos or
+    Serendia, are counted, with subtotals for each town's territory. You can
+    provide a [worker city] to just display what is being produced by workers
+    from that city. Output per real-time hour is estimated for worked nodes
+    whose workload and distance are known, from the "workloads" file
+    described below or the node data; the built in node data has none yet.
 
 nodes path <node a> [node b]
     Shows the best way to connect <node a> to your network, or to [node b] if
-    that is given.
+    that is given. When several ways cost the same, those with the fewest
+    connections are shown, up to 10 of them.
+
+nodes buy <node> [--with-path]
+    Adds <node> to your "owned" file. It must be next to a node you already
+    own, unless --with-path is given, in which case the nodes on the best path
+    connecting it to your network, as nodes path shows, are bought too.
+
+nodes sell <node> [--cascade]
+    Removes <node> from your "owned" file. If that would cut other owned nodes
+    off from every town, or <node> has a worker assigned, nothing is sold
+    unless --cascade is given, in which case you are asked to confirm selling
+    all of them.
+
+nodes assign [item...]
+    Shows the best assignment of workers to your production nodes, as changes
+    from the workers assigned in your "owned" file. The "workers" file lists
+    how many workers each town has, one town per line as "<town> -- <count>".
+    As many nodes as possible are assigned with the least total travel, and
+    nodes producing any of the [item...] given are assigned first.
+
+nodes connect-all <node> [node...]
+    Shows the cheapest set of nodes to buy to connect all the given nodes to
+    your network at once, which can be cheaper than connecting them one at a
+    time when they can share a route.
+
+nodes graph [--around <node> [--hops <n>]] [--region <region>]
+            [--path <node a> [--to <node b>]]
+    Writes the node network in the Graphviz DOT language, for rendering with
+    a tool such as dot. With --around only the nodes within --hops
+    connections, 3 by default, of <node> are included, and with --region only
+    the nodes in <region>. With --path the best path from <node a> to your
+    network, or to <node b> if --to is given, is highlighted. Towns are bold
+    boxes, production nodes are rounded boxes, owned nodes are filled, and
+    nodes with assigned workers are green.
+
+nodes plan <cp> [weights file]
+    Shows which production nodes to buy, and the nodes needed to connect them,
+    to get the most new items for <cp> contribution points, in the order to
+    buy them. If a [weights file] is given it should be a table file with Item
+    and Weight columns, and the plan will get the most total weight instead;
+    items not listed are then worth nothing.
+
+nodes rank [prices file]
+    Ranks the production nodes by the silver per real-time hour they are
+    expected to earn for each contribution point needed to buy and connect
+    them, best first, so you can see which pay back soonest. The [prices
+    file], "prices" in the current directory by default, should be a table
+    file with Item and Price columns; nodes producing nothing priced are left
+    out. Output is estimated as for plan goal, and each cycle earns the price
+    of the best priced item the node produces, which is shown. Nodes whose
+    output cannot be estimated are not ranked but listed after the rest, by
+    contribution points. Owned nodes are ranked by their own contribution
+    points.
 
 nodes search [costs] <phrase>
     Shows information about the nodes that match the search <phrase> given.
     If you give the "costs" option, the contribution points needed to connect
     each matching nodes to your network will be shown as well.
 
-table search <file> <phrase>
-    Shows the lines in the table <file> that match the search <phrase> given.
+items list
+    Lists every item the production nodes produce, with how many nodes
+    produce it and how many of those you own.
+
+items where <item>
+    Shows every production node producing <item>, with the town of its
+    closest worker, whether you own it, and the contribution points needed
+    to connect it to your network, cheapest first. The <item> may be the
+    start of its name or any part of it, as long as only one item matches.
+
+recipes tree <item>
+    Shows what is needed to make one <item>, through each processing,
+    cooking, or alchemy step down to the raw items. Each raw item is marked
+    as supplied, with the owned nodes with workers assigned that produce it,
+    as bought from vendors, or as missing. The <item> may be shortened as
+    with items where.
+
+plan goal <item> <qty/day>
+    Shows what is needed each day to make <qty/day> of <item>, down to the
+    raw items as recipes tree shows, compared with what the owned nodes with
+    workers assigned produce. Then it lists the production nodes to buy, and
+    the nodes needed to connect them, to make up the difference, cheapest
+    first. Output per day is estimated as for the nodes command, split evenly
+    across the items a node produces, with workers from each new node's
+    closest worker town. Nodes whose output cannot be estimated are listed
+    but not counted or bought.
+
+trade route <from town> <to town>
+    Shows the fewest connections between the towns, the straight-line
+    distance between them and the resulting distance bonus for trading goods,
+    and the cheapest route to connect them. The trade nodes on that route are
+    listed, since they must be owned for the trade to count as connected.
+    The distance is only known for towns with a position in the node data,
+    and the bonus only if the node data also has a tradeBonus. The built in
+    node data has neither, so give your own copy with --nodes to use them.
+
+table search [--count] <file> <phrase>
+    Shows the lines in the table <file> that match the search <phrase> given,
+    with the matches highlighted. With --count it instead shows how many lines
+    matched in each column.
 
 table search-column <file> <column> <phrase>
     Shows the lines in the table <file> that match the search <phrase> given,
     but only within the <column> given.
 
+table join [--left] <file a> <column a> <file b> <column b> [phrase]
+    Shows the lines of table <file a> joined with the lines of table <file b>
+    whose <column b> matches their <column a>, ignoring case. Lines of <file
+    a> with no match are left out, unless --left is given, in which case they
+    are shown with the columns from <file b> empty. If a [phrase] is given,
+    only the joined lines that match it are shown, as table search does.
+
+table validate <file>
+    Checks that the table <file> can be read and lists every problem with it,
+    by line and column, exiting with a nonzero status if there were any.
+
+table add <file> <column=value>...
+table set <file> <match column> <match> <column=value>...
+table delete <file> <column> <phrase>
+    Edit the table <file> in place, rewriting it aligned and keeping the
+    previous version as <file>.bak. Add appends a line with the values given
+    and the other columns empty. Set changes the values on every line whose
+    <match column> is <match>, ignoring case. Delete removes every line with
+    the <phrase> in the <column>, as table search-column would show, but will
+    not remove the last line. With --format json or csv, the lines added,
+    changed, or removed are printed in that format.
+
+table query <file> <query>
+    Shows the lines in the table <file> that match the <query>, which is:
+        [select <column>, ...] [[where] <condition>]
+        [order by <column> [asc|desc], ...] [limit <n>]
+    A <condition> is "<column> <op> <value>", where <op> is one of = != < <=
+    > >= or ~ and !~ for regular expression matches, and conditions can be
+    combined with and, or, not, and parentheses. Values compare as numbers
+    when both sides are numbers, and otherwise as words ignoring case; order
+    by sorts the same way. Names and values may be several words, but need
+    double quotes if they include a keyword or operator; commas only separate
+    the select and order by columns. For example:
+        table query prices "select Item, Price where Price > 5000 and
+            Region = Mediah order by Price desc limit 10"
+
 csv
     This will translate a CSV file from stdin to a table file to stdout.
 
+convert <from> <to> [file]
+    Converts the [file], or stdin, from one format to another, writing to
+    stdout. The formats are table, csv, tsv, json for an array of objects
+    with the header as their keys, and markdown, or md, for a Markdown table.
+
+lint
+    Checks the node data for problems and lists all of them: connections
+    listed from only one end or to nodes that do not exist, duplicate nodes,
+    production nodes that produce nothing, nodes not connected to the rest of
+    the network, and towns that cannot reach the other towns. The counts of
+    known connections not entered yet and of production nodes with no
+    workload or distance are shown too. It exits with a nonzero status if
+    there were any problems.
+
+shell
+    Starts an interactive shell that keeps the node data loaded and accepts
+    the nodes, items, recipes, plan, trade, and table commands. Node and item
+    names can be completed with the tab key. The shell also has "own <node>",
+    "unown <node>", and "worker <node> [worker city] [worker type and stats]"
+    commands to try out changes, and "save" to write them to the "owned" file.
+
 If you have a file named "owned" in the current directory, it will be read as
 the list of nodes you own, one node per line. If a line ends with
 " -- <worker city>" it will mark the node as having a worker assigned to it
-from the <worker city>.
+from the <worker city>. That may be followed by
+" -- <type> [work speed] [movement speed] [stamina]" to give the worker's
+type, goblin, human, or giant, and its stats if they differ from a new
+worker's; a new human is assumed for estimates otherwise. Any line with a
+name that cannot be found stops the command, unless the --lenient option is
+given, in which case the line is skipped with a warning.
+
+Wherever a node name is given it may be shortened to the start of the name,
+or the starts of some of its words, or its initials, as long as only one node
+matches, or only a node and its production nodes. For example, "pil hum" or
+"psh" for "Pilgrim's Sanctum: Humility".
 
 Example "owned" file showing a common case where a Velian worker is working on
 the Ancient Stone Chamber excavation node:
@@ -52,27 +236,85 @@ Bartali Farm
 Toscani Farm
 Forest of Seclusion
 Ancient Stone Chamber
-Ancient Stone Chamber: A -- Velia
+Ancient Stone Chamber: A -- Velia -- goblin 60
+
+If you have a file named "workloads" in the current directory, it should be a
+table file with Node, Workload, and Distance columns giving the workload of
+each production node listed and the distance to it from its closest worker
+town, as the game shows them. These are used to estimate output, and override
+any in the node data, which has none built in yet. For example:
+
++-----------------+----------+----------+
+| Node            | Workload | Distance |
++-----------------+----------+----------+
+| Toscani Farm: A | 100      | 1,200    |
++-----------------+----------+----------+
+
+The node data itself is built into the tool, but you can use your own
+corrected copy by giving its path with the --nodes option or with the
+BDOT_NODES environment variable. The format is documented in bdo/data.go and
+the built in copy is bdo/nodes.json in the source tree, which makes a good
+starting point. The recipe data works the same way, with the --recipes option
+or the BDOT_RECIPES environment variable, documented in bdo/recipes.go and
+built in from bdo/recipes.json. The built in recipe quantities are
+placeholders not checked against the game, so correct them in your own copy.
 `, os.Args[0])
-	if msg != "" {
-		fmt.Println("")
-		fmt.Fprintln(os.Stderr, msg)
-	}
-	os.Exit(exitCode)
 }
 
 func main() {
 	args := os.Args[1:]
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		option := args[0]
+		args = args[1:]
+		if option == "--lenient" {
+			lenient = true
+			continue
+		}
+		if len(args) < 1 {
+			help(fmt.Sprintf("%s needs a value", option), 1)
+		}
+		value := args[0]
+		args = args[1:]
+		switch option {
+		case "--nodes":
+			nodesFile = value
+		case "--recipes":
+			recipesFile = value
+		case "--format":
+			switch value {
+			case "text", "json", "csv":
+				outputFormat = value
+			default:
+				help(fmt.Sprintf("Unknown format %q.", value), 1)
+			}
+		default:
+			help(fmt.Sprintf("Unknown option %q.", option), 1)
+		}
+	}
 	if len(args) == 0 {
 		help("", 0)
 	}
 	switch args[0] {
 	case "nodes":
-		nodesCommand(args[1:])
+		nodesCommand(loadGraph(), args[1:])
+	case "items":
+		itemsCommand(loadGraph(), args[1:])
+	case "recipes":
+		recipesCommand(loadGraph(), args[1:])
+	case "plan":
+		planCommand(loadGraph(), args[1:])
+	case "trade":
+		tradeCommand(loadGraph(), args[1:])
 	case "table":
-		table(args[1:])
+		tableCommand(args[1:])
 	case "csv":
 		csvToTable(args[1:])
+	case "lint":
+		lint(args[1:])
+	case "convert":
+		convert(args[1:])
+	case "shell":
+		shell(args[1:])
 	default:
 		help(fmt.Sprintf("Unknown command %q.", args[0]), 1)
 	}
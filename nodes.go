@@ -1,77 +1,18 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
-)
-
-var nodes = map[string]*node{}
-
-type node struct {
-	name               string
-	contributionPoints int
-	owned              bool
-	closestWorker      string
-	assignedWorker     string
-	produces           []string
-}
-
-func addNode(name string, cp int) {
-	nodes[name] = &node{name: name, contributionPoints: cp}
-	if cp == 0 {
-		nodes[name].owned = true
-	}
-}
-
-func addProductionNode(parent string, name string, cp int, closestWorker string, produces ...string) {
-	name = parent + ": " + name
-	addNode(name, cp)
-	addConnection(parent, name)
-	nodes[name].closestWorker = closestWorker
-	for _, p := range produces {
-		nodes[name].produces = append(nodes[name].produces, p)
-	}
-	addConnection(name, parent)
-}
-
-func (n *node) String() string {
-	var s string
-	if n.owned {
-		s = fmt.Sprintf("%s (%d) owned", n.name, n.contributionPoints)
-	} else {
-		s = fmt.Sprintf("%s [%d]", n.name, n.contributionPoints)
-	}
-	if n.closestWorker != "" {
-		s += ", closest worker from " + n.closestWorker
-	}
-	if len(n.produces) > 0 {
-		s += ", produces:"
-		for i, p := range n.produces {
-			if i != 0 {
-				s += ","
-			}
-			s += " " + p
-		}
-	}
-	return s
-}
-
-var connections = map[string]map[string]struct{}{}
 
-func addConnection(a string, b string) {
-	if _, ok := connections[a]; !ok {
-		connections[a] = map[string]struct{}{b: struct{}{}}
-	} else {
-		connections[a][b] = struct{}{}
-	}
-}
+	"github.com/gholt/bdot/bdo"
+)
 
 type costNode struct {
 	cost int
-	node *node
+	node *bdo.Node
 }
 
 type costNodes []*costNode
@@ -91,49 +32,159 @@ func (cns costNodes) Less(x, y int) bool {
 	} else if v > 0 {
 		return false
 	}
-	return cns[x].node.name < cns[y].node.name
+	return cns[x].node.Name < cns[y].node.Name
+}
+
+// readWeights reads a table file with "Item" and "Weight" columns, as used
+// by nodes plan.
+func readWeights(filename string) map[string]float64 {
+	return readItemValues(filename, "Weight")
+}
+
+// readPrices reads a table file with "Item" and "Price" columns, as used by
+// nodes rank. Prices may have commas, such as 1,200.
+func readPrices(filename string) map[string]float64 {
+	return readItemValues(filename, "Price")
+}
+
+// readItemValues reads a table file with "Item" and the named value column,
+// ignoring any other columns, and returns the value of each item.
+func readItemValues(filename string, column string) map[string]float64 {
+	header, data := tableRead(filename)
+	itemColumn := -1
+	valueColumn := -1
+	for i, c := range header {
+		switch strings.ToLower(c) {
+		case "item":
+			itemColumn = i
+		case strings.ToLower(column):
+			valueColumn = i
+		}
+	}
+	if itemColumn == -1 || valueColumn == -1 {
+		errnil(fmt.Errorf("%s needs Item and %s columns", filename, column))
+	}
+	values := map[string]float64{}
+	for _, row := range data {
+		v, ok := queryNumber(row[valueColumn])
+		if !ok {
+			errnil(fmt.Errorf("%s: invalid %s %q for %q", filename, strings.ToLower(column), row[valueColumn], row[itemColumn]))
+		}
+		values[row[itemColumn]] = v
+	}
+	return values
+}
+
+// findNode returns the name of the node given, as resolved by
+// bdo.Graph.Resolve, or exits with an error if there is no such node.
+func findNode(g *bdo.Graph, name string) string {
+	n, err := g.Resolve(name)
+	if err != nil {
+		help(err.Error(), 1)
+	}
+	return n.Name
+}
+
+// findRegion returns the region name refers to, ignoring case, which may be
+// the start of just one region's name; or exits with an error listing the
+// regions.
+func findRegion(g *bdo.Graph, name string) string {
+	regions := g.Regions()
+	var matches []string
+	for _, r := range regions {
+		if strings.EqualFold(r, name) {
+			return r
+		}
+		if name != "" && strings.HasPrefix(strings.ToLower(r), strings.ToLower(name)) {
+			matches = append(matches, r)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0]
+	}
+	help(fmt.Sprintf("Could not find region %q; it should be one of %s.", name, strings.Join(regions, ", ")), 1)
+	return ""
+}
+
+// nodesFileName returns the --nodes file, or the file named by the
+// BDOT_NODES environment variable, or "" to use the built in data.
+func nodesFileName() string {
+	if nodesFile != "" {
+		return nodesFile
+	}
+	return os.Getenv("BDOT_NODES")
 }
 
-func nodesCommand(args []string) {
+// loadGraph returns the node graph from the nodesFileName file, or the built
+// in data; with the nodes listed in the "owned" file, if any, marked as owned,
+// and the workloads from the "workloads" file, if any.
+func loadGraph() *bdo.Graph {
+	nodesFile := nodesFileName()
+	var g *bdo.Graph
+	var err error
+	if nodesFile == "" {
+		g, err = bdo.DefaultGraph()
+	} else {
+		g, err = bdo.LoadFile(nodesFile)
+	}
+	errnil(err)
 	f, err := os.Open("owned")
 	if err == nil {
-		scanner := bufio.NewScanner(f)
-		lineNumber := 0
-		for scanner.Scan() {
-			lineNumber++
-			line := scanner.Text()
-			name := line
-			var worker string
-			t := strings.SplitN(line, " -- ", 2)
-			if len(t) == 2 {
-				name = t[0]
-				workerL := strings.ToLower(t[1])
-				for n := range nodes {
-					if strings.ToLower(n) == workerL {
-						worker = n
-					}
-				}
-				if worker == "" {
-					help(fmt.Sprintf("Could not find node %q referenced on line %d: %q\n", t[1], lineNumber, line), 1)
-				}
-			}
-			nameL := strings.ToLower(name)
-			for n := range nodes {
-				if strings.ToLower(n) == nameL {
-					nodes[n].owned = true
-					if worker != "" {
-						nodes[n].assignedWorker = worker
-					}
-					nameL = ""
-					break
-				}
-			}
-			if nameL != "" {
-				help(fmt.Sprintf("Could not find node %q referenced on line %d: %q\n", name, lineNumber, line), 1)
-			}
+		var warnings []error
+		if lenient {
+			warnings, err = g.LoadOwnedLenient(f)
+		} else {
+			err = g.LoadOwned(f)
 		}
 		f.Close()
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "Skipped in owned: %s\n", w)
+		}
+		if err != nil {
+			help(err.Error()+"\n", 1)
+		}
+	}
+	if _, err := os.Stat("workloads"); err == nil {
+		readWorkloads(g, "workloads")
+	}
+	return g
+}
+
+// readWorkloads reads a table file with Node, Workload, and Distance columns
+// and sets them on each production node listed, overriding the node data.
+func readWorkloads(g *bdo.Graph, filename string) {
+	header, data := tableRead(filename)
+	columns := map[string]int{}
+	for i, c := range header {
+		columns[strings.ToLower(c)] = i
+	}
+	for _, c := range []string{"node", "workload", "distance"} {
+		if _, ok := columns[c]; !ok {
+			errnil(fmt.Errorf("%s needs Node, Workload, and Distance columns", filename))
+		}
 	}
+	for _, row := range data {
+		n, err := g.Resolve(row[columns["node"]])
+		if err != nil {
+			errnil(fmt.Errorf("%s: %s", filename, err))
+		}
+		if n.Kind != bdo.KindProduction {
+			errnil(fmt.Errorf("%s: %s is not a production node", filename, n.Name))
+		}
+		workload, ok := queryNumber(row[columns["workload"]])
+		if !ok || workload <= 0 {
+			errnil(fmt.Errorf("%s: invalid workload %q for %q", filename, row[columns["workload"]], n.Name))
+		}
+		distance, ok := queryNumber(row[columns["distance"]])
+		if !ok || distance <= 0 {
+			errnil(fmt.Errorf("%s: invalid distance %q for %q", filename, row[columns["distance"]], n.Name))
+		}
+		n.Workload = int(workload)
+		n.Distance = distance
+	}
+}
+
+func nodesCommand(g *bdo.Graph, args []string) {
 	var cmd string
 	if len(args) > 0 {
 		cmd = args[0]
@@ -147,35 +198,41 @@ func nodesCommand(args []string) {
 		if len(args) > 2 {
 			help(fmt.Sprintf("Path request had too many parameters"), 1)
 		}
-		var nodeA string
+		nodeA := findNode(g, args[0])
 		var nodeB string
-		nodeAL := strings.ToLower(args[0])
-		var nodeBL string
 		if len(args) == 2 {
-			nodeBL = strings.ToLower(args[1])
-		}
-		for n := range nodes {
-			nL := strings.ToLower(n)
-			if nL == nodeAL {
-				nodeA = n
-			}
-			if nL == nodeBL {
-				nodeB = n
-			}
-			if nodeA != "" && (nodeB != "" || nodeBL == "") {
-				break
-			}
-		}
-		if nodeA == "" {
-			help(fmt.Sprintf("Could not find node %q.", args[0]), 1)
-		}
-		if nodeB == "" && nodeBL != "" {
-			help(fmt.Sprintf("Could not find node %q.", args[1]), 1)
+			nodeB = findNode(g, args[1])
 		}
 		if nodeA == nodeB {
 			help(fmt.Sprintf("Both nodes seem to be the same node: %q %q.", args[0], args[1]), 1)
 		}
-		bestCost, bestPaths := bestPaths(nodeA, nodeB)
+		bestCost, bestPaths, total := g.BestPathsCount(nodeA, nodeB)
+		if outputFormat != "text" {
+			options := [][]*bdo.Node{}
+			rows := [][]string{append([]string{"Option", "Cost"}, nodeCSVHeader...)}
+			for i, pth := range bestPaths {
+				var option []*bdo.Node
+				for j := len(pth) - 1; j >= 0; j-- {
+					option = append(option, g.Node(pth[j]))
+					rows = append(rows, append([]string{strconv.Itoa(i + 1), strconv.Itoa(bestCost)}, nodeCSVRow(g.Node(pth[j]))...))
+				}
+				options = append(options, option)
+			}
+			if outputFormat == "csv" {
+				printCSV(rows)
+				return
+			}
+			printJSON(struct {
+				From    string        `json:"from"`
+				To      string        `json:"to,omitempty"`
+				Cost    int           `json:"cost"`
+				Options [][]*bdo.Node `json:"options"`
+				// Total is how many options there are, of which only the
+				// first are listed if there are many.
+				Total int `json:"total"`
+			}{nodeA, nodeB, bestCost, options, total})
+			return
+		}
 		if nodeB == "" {
 			fmt.Printf("%d contribution points are needed to connect to %s.\n", bestCost, nodeA)
 		} else {
@@ -186,17 +243,216 @@ func nodesCommand(args []string) {
 				fmt.Printf("Option %d:\n", i+1)
 			}
 			for j := len(pth) - 1; j >= 0; j-- {
-				node := nodes[pth[j]]
-				if node.owned {
-					if node.contributionPoints == 0 {
-						fmt.Printf("          %s (always owned)\n", node.name)
+				node := g.Node(pth[j])
+				if node.Owned {
+					if node.ContributionPoints == 0 {
+						fmt.Printf("          %s (always owned)\n", node.Name)
 					} else {
-						fmt.Printf("          %s (already owned for %d)\n", node.name, node.contributionPoints)
+						fmt.Printf("          %s (already owned for %d)\n", node.Name, node.ContributionPoints)
 					}
 				} else {
-					fmt.Printf("   %2d for %s\n", node.contributionPoints, node.name)
+					fmt.Printf("   %2d for %s\n", node.ContributionPoints, node.Name)
+				}
+			}
+		}
+		if total > len(bestPaths) {
+			fmt.Printf("Only %d of the %d options with this cost in the fewest connections are shown.\n", len(bestPaths), total)
+		}
+	case "assign":
+		nodesAssign(g, args)
+	case "buy":
+		nodesBuy(g, args)
+	case "sell":
+		nodesSell(g, args)
+	case "connect-all":
+		if len(args) < 1 {
+			help("connect-all needs at least one <node>", 1)
+		}
+		var targets []string
+		for _, arg := range args {
+			targets = append(targets, findNode(g, arg))
+		}
+		cost, buy := g.ConnectAll(targets)
+		if cost < 0 {
+			help(fmt.Sprintf("Could not connect all of %s to your network.", strings.Join(targets, ", ")), 1)
+		}
+		if outputFormat != "text" {
+			buyNodes := []*bdo.Node{}
+			rows := [][]string{nodeCSVHeader}
+			for _, n := range buy {
+				buyNodes = append(buyNodes, g.Node(n))
+				rows = append(rows, nodeCSVRow(g.Node(n)))
+			}
+			if outputFormat == "csv" {
+				printCSV(rows)
+				return
+			}
+			printJSON(struct {
+				Targets []string    `json:"targets"`
+				Cost    int         `json:"cost"`
+				Buy     []*bdo.Node `json:"buy"`
+			}{targets, cost, buyNodes})
+			return
+		}
+		fmt.Printf("%d contribution points are needed to connect %s.\n", cost, strings.Join(targets, ", "))
+		for _, n := range buy {
+			fmt.Printf("   %2d for %s\n", g.Node(n).ContributionPoints, n)
+		}
+	case "graph":
+		opts := &bdo.DOTOptions{}
+		var around, pathA, pathB, region string
+		hops := 3
+		for len(args) > 0 {
+			if len(args) < 2 {
+				help(fmt.Sprintf("graph option %q needs a value", args[0]), 1)
+			}
+			switch args[0] {
+			case "--around":
+				around = findNode(g, args[1])
+			case "--hops":
+				var err error
+				if hops, err = strconv.Atoi(args[1]); err != nil || hops < 0 {
+					help(fmt.Sprintf("Invalid number of hops %q.", args[1]), 1)
+				}
+			case "--path":
+				pathA = findNode(g, args[1])
+			case "--to":
+				pathB = findNode(g, args[1])
+			case "--region":
+				region = findRegion(g, args[1])
+			default:
+				help(fmt.Sprintf("Unknown graph option %q.", args[0]), 1)
+			}
+			args = args[2:]
+		}
+		if around != "" {
+			opts.Include = g.Around(around, hops)
+		}
+		if region != "" {
+			inRegion := g.InRegion(region)
+			if opts.Include == nil {
+				opts.Include = inRegion
+			} else {
+				for n := range opts.Include {
+					if _, ok := inRegion[n]; !ok {
+						delete(opts.Include, n)
+					}
+				}
+			}
+		}
+		if pathA != "" {
+			_, bestPaths := g.BestPaths(pathA, pathB)
+			if len(bestPaths) > 0 {
+				opts.Highlight = bestPaths[0]
+				if opts.Include != nil {
+					for _, n := range opts.Highlight {
+						opts.Include[n] = struct{}{}
+					}
+				}
+			}
+		} else if pathB != "" {
+			help("graph option --to needs --path as well", 1)
+		}
+		errnil(g.WriteDOT(os.Stdout, opts))
+	case "plan":
+		if len(args) < 1 || len(args) > 2 {
+			help("plan needs a <cp> budget and optionally a [weights file]", 1)
+		}
+		budget, err := strconv.Atoi(args[0])
+		if err != nil || budget < 0 {
+			help(fmt.Sprintf("Invalid contribution point budget %q.", args[0]), 1)
+		}
+		var weights map[string]float64
+		if len(args) == 2 {
+			weights = readWeights(args[1])
+		}
+		plan := g.Plan(budget, weights)
+		if outputFormat == "json" {
+			if plan == nil {
+				plan = []*bdo.Purchase{}
+			}
+			printJSON(struct {
+				Budget    int             `json:"budget"`
+				Purchases []*bdo.Purchase `json:"purchases"`
+			}{budget, plan})
+			return
+		}
+		if outputFormat == "csv" {
+			rows := [][]string{{"For", "Buy", "CP"}}
+			for _, p := range plan {
+				for _, n := range p.Buy {
+					rows = append(rows, []string{p.Node, n, strconv.Itoa(g.Node(n).ContributionPoints)})
+				}
+			}
+			printCSV(rows)
+			return
+		}
+		if len(plan) == 0 {
+			fmt.Printf("Nothing worth buying was found for %d contribution points.\n", budget)
+			return
+		}
+		cost := 0
+		value := 0.0
+		for _, p := range plan {
+			cost += p.Cost
+			value += p.Value
+		}
+		fmt.Printf("Spend %d of %d contribution points for a value of %g:\n", cost, budget, value)
+		for _, p := range plan {
+			fmt.Printf("For %s (%g):\n", strings.Join(p.Items, ", "), p.Value)
+			for _, n := range p.Buy {
+				fmt.Printf("   %2d for %s\n", g.Node(n).ContributionPoints, n)
+			}
+		}
+	case "rank":
+		if len(args) > 1 {
+			help("rank takes just an optional [prices file]", 1)
+		}
+		filename := "prices"
+		if len(args) == 1 {
+			filename = args[0]
+		}
+		ranks := g.Rank(readPrices(filename))
+		if outputFormat == "json" {
+			if ranks == nil {
+				ranks = []*bdo.Rank{}
+			}
+			printJSON(ranks)
+			return
+		}
+		if outputFormat == "csv" {
+			rows := [][]string{{"Node", "Item", "Owned", "CP", "Estimated", "Silver/Hour", "Silver/Hour/CP"}}
+			for _, r := range ranks {
+				perHour, perCP := "", ""
+				if r.Estimated {
+					perHour = strconv.FormatFloat(r.SilverPerHour, 'f', 0, 64)
+					perCP = strconv.FormatFloat(r.SilverPerCP, 'f', 0, 64)
 				}
+				rows = append(rows, []string{r.Node, r.Item, strconv.FormatBool(r.Owned), strconv.Itoa(r.Cost), strconv.FormatBool(r.Estimated), perHour, perCP})
 			}
+			printCSV(rows)
+			return
+		}
+		report := [][]string{{"Node", "Item", "Owned", "CP", "Silver/Hour", "Silver/Hour/CP"}, nil}
+		unestimated := 0
+		for _, r := range ranks {
+			owned := "no"
+			if r.Owned {
+				owned = "yes"
+			}
+			if !r.Estimated {
+				if unestimated == 0 && len(report) > 2 {
+					report = append(report, nil)
+				}
+				unestimated++
+				report = append(report, []string{r.Node, r.Item, owned, strconv.Itoa(r.Cost), "?", "?"})
+				continue
+			}
+			report = append(report, []string{r.Node, r.Item, owned, strconv.Itoa(r.Cost), strconv.FormatFloat(r.SilverPerHour, 'f', 0, 64), strconv.FormatFloat(r.SilverPerCP, 'f', 0, 64)})
+		}
+		printTable(report)
+		if unestimated > 0 {
+			fmt.Printf("No workload or distance is known to estimate %d of these, so they are not ranked and are listed by CP instead.\n", unestimated)
 		}
 	case "search":
 		if len(args) < 1 {
@@ -213,15 +469,15 @@ func nodesCommand(args []string) {
 		} else {
 			search = strings.ToLower(strings.Join(args, " "))
 		}
-		var matches []string
-		for _, n := range nodes {
-			if strings.Contains(strings.ToLower(n.name), search) {
-				matches = append(matches, n.name)
+		var matches []*bdo.Node
+		for _, n := range g.Nodes() {
+			if strings.Contains(strings.ToLower(n.Name), search) {
+				matches = append(matches, n)
 				continue
 			}
-			for _, p := range n.produces {
+			for _, p := range n.Produces {
 				if strings.Contains(strings.ToLower(p), search) {
-					matches = append(matches, n.name)
+					matches = append(matches, n)
 					break
 				}
 			}
@@ -229,65 +485,189 @@ func nodesCommand(args []string) {
 		if costs {
 			var cns costNodes
 			for _, n := range matches {
-				bestCost, _ := bestPaths(n, "")
-				cns = append(cns, &costNode{cost: bestCost, node: nodes[n]})
+				bestCost, _ := g.BestPaths(n.Name, "")
+				cns = append(cns, &costNode{cost: bestCost, node: n})
 			}
 			sort.Sort(cns)
-			for _, cn := range cns {
-				fmt.Printf("[%d] %s\n", cn.cost, cn.node)
+			switch outputFormat {
+			case "json":
+				type costJSON struct {
+					Cost int `json:"cost"`
+					*bdo.Node
+				}
+				results := []costJSON{}
+				for _, cn := range cns {
+					results = append(results, costJSON{cn.cost, cn.node})
+				}
+				printJSON(results)
+			case "csv":
+				rows := [][]string{append([]string{"Cost"}, nodeCSVHeader...)}
+				for _, cn := range cns {
+					rows = append(rows, append([]string{strconv.Itoa(cn.cost)}, nodeCSVRow(cn.node)...))
+				}
+				printCSV(rows)
+			default:
+				for _, cn := range cns {
+					fmt.Printf("[%d] %s\n", cn.cost, cn.node)
+				}
 			}
 		} else {
-			sort.Strings(matches)
-			for _, n := range matches {
-				fmt.Println(nodes[n])
+			switch outputFormat {
+			case "json":
+				if matches == nil {
+					matches = []*bdo.Node{}
+				}
+				printJSON(matches)
+			case "csv":
+				rows := [][]string{nodeCSVHeader}
+				for _, n := range matches {
+					rows = append(rows, nodeCSVRow(n))
+				}
+				printCSV(rows)
+			default:
+				for _, n := range matches {
+					fmt.Println(n)
+				}
 			}
 		}
 	default:
-		var filter string
-		if cmd != "" {
-			filterL := strings.ToLower(cmd)
-			for n := range nodes {
-				if filterL == strings.ToLower(n) {
-					filter = n
-					break
-				}
+		var region string
+		if cmd == "--region" {
+			if len(args) < 1 {
+				help("--region needs a <region>", 1)
 			}
-			if filter == "" {
-				help(fmt.Sprintf("Could not find node %q.", cmd), 1)
+			region = findRegion(g, args[0])
+			cmd = ""
+			if len(args) > 1 {
+				cmd = args[1]
 			}
 		}
+		var filter string
+		if cmd != "" {
+			filter = findNode(g, cmd)
+		}
+		// Subtotals are by region, or by territory within a region.
+		type subtotal struct {
+			Count              int `json:"count"`
+			ContributionPoints int `json:"cp"`
+		}
+		subtotals := map[string]*subtotal{}
 		count := 0
 		cp := 0
 		production := 0
 		produces := map[string]int{}
-		var notProducing []*node
+		var notProducing []*bdo.Node
+		var working []*bdo.Node
 		workers := 0
-		for _, node := range nodes {
-			if node.owned {
+		// The json and csv formats list the nodes this report covers.
+		reported := []*bdo.Node{}
+		for _, node := range g.Nodes() {
+			if region != "" && node.Region != region {
+				continue
+			}
+			if node.Owned && (filter == "" || node.AssignedWorker == filter) {
+				reported = append(reported, node)
+			}
+			if node.Owned {
 				count++
-				cp += node.contributionPoints
-				if len(node.produces) > 0 {
+				cp += node.ContributionPoints
+				area := node.Region
+				if region != "" {
+					area = node.Territory
+				}
+				if subtotals[area] == nil {
+					subtotals[area] = &subtotal{}
+				}
+				subtotals[area].Count++
+				subtotals[area].ContributionPoints += node.ContributionPoints
+				if len(node.Produces) > 0 {
 					production++
 					if filter == "" {
-						if node.assignedWorker == "" {
+						if node.AssignedWorker == "" {
 							notProducing = append(notProducing, node)
 						} else {
 							workers++
-							for _, p := range node.produces {
+							working = append(working, node)
+							for _, p := range node.Produces {
 								produces[p]++
 							}
 						}
-					} else if node.assignedWorker == filter {
+					} else if node.AssignedWorker == filter {
 						workers++
-						for _, p := range node.produces {
+						working = append(working, node)
+						for _, p := range node.Produces {
 							produces[p]++
 						}
 					}
 				}
 			}
 		}
+		estimates := []*bdo.Estimate{}
+		var unestimated []string
+		for _, n := range working {
+			if e := g.Estimate(n.Name, n.AssignedWorker); e != nil {
+				estimates = append(estimates, e)
+			} else {
+				unestimated = append(unestimated, n.Name)
+			}
+		}
+		switch outputFormat {
+		case "json":
+			if filter == "" {
+				if notProducing == nil {
+					notProducing = []*bdo.Node{}
+				}
+				printJSON(struct {
+					Region             string               `json:"region,omitempty"`
+					Count              int                  `json:"count"`
+					ContributionPoints int                  `json:"cp"`
+					Subtotals          map[string]*subtotal `json:"subtotals"`
+					Production         int                  `json:"production"`
+					Workers            int                  `json:"workers"`
+					Produces           map[string]int       `json:"produces"`
+					NotProducing       []*bdo.Node          `json:"notProducing"`
+					Estimates          []*bdo.Estimate      `json:"estimates"`
+					Nodes              []*bdo.Node          `json:"nodes"`
+				}{region, count, cp, subtotals, production, workers, produces, notProducing, estimates, reported})
+			} else {
+				printJSON(struct {
+					Worker    string          `json:"worker"`
+					Workers   int             `json:"workers"`
+					Produces  map[string]int  `json:"produces"`
+					Estimates []*bdo.Estimate `json:"estimates"`
+					Nodes     []*bdo.Node     `json:"nodes"`
+				}{filter, workers, produces, estimates, reported})
+			}
+			return
+		case "csv":
+			rows := [][]string{nodeCSVHeader}
+			for _, n := range reported {
+				rows = append(rows, nodeCSVRow(n))
+			}
+			printCSV(rows)
+			return
+		}
 		if filter == "" {
-			fmt.Printf("You own %d nodes for %d contribution points.\n", count, cp)
+			if region != "" {
+				fmt.Printf("You own %d nodes in %s for %d contribution points.\n", count, region, cp)
+			} else {
+				fmt.Printf("You own %d nodes for %d contribution points.\n", count, cp)
+			}
+			if len(subtotals) > 1 {
+				fmt.Println()
+				areas := make([]string, 0, len(subtotals))
+				for a := range subtotals {
+					areas = append(areas, a)
+				}
+				sort.Strings(areas)
+				for _, a := range areas {
+					name := a
+					if name == "" {
+						name = "Elsewhere"
+					}
+					fmt.Printf("    %s: %d nodes for %d contribution points\n", name, subtotals[a].Count, subtotals[a].ContributionPoints)
+				}
+			}
 			if production > 0 {
 				fmt.Printf("\n%d are production nodes, of which %d are assigned workers producing the following items:\n", production, workers)
 				ps := make([]string, 0, len(produces))
@@ -303,10 +683,11 @@ func nodesCommand(args []string) {
 					}
 				}
 			}
+			printEstimates(estimates, unestimated)
 			if len(notProducing) > 0 {
 				fmt.Printf("\nYou have %d production nodes without assigned workers:\n", len(notProducing))
 				for _, n := range notProducing {
-					fmt.Printf("    %s could produce: %s\n", n.name, strings.Join(n.produces, ", "))
+					fmt.Printf("    %s could produce: %s\n", n.Name, strings.Join(n.Produces, ", "))
 				}
 			}
 		} else {
@@ -323,68 +704,30 @@ func nodesCommand(args []string) {
 					fmt.Printf("    %s\n", p)
 				}
 			}
+			printEstimates(estimates, unestimated)
 		}
 	}
 }
 
-func bestPaths(nodeA string, nodeB string) (int, [][]string) {
-	deadends := map[string]struct{}{}
-	visited := map[string]struct{}{nodeA: struct{}{}}
-	cost := 0
-	bestCost := int(^uint(0) >> 1)
-	var bestPaths [][]string
-	var branch func(pth []string, n string) bool
-	branch = func(pth []string, n string) bool {
-		if !nodes[n].owned {
-			cost += nodes[n].contributionPoints
-		}
-		visited[n] = struct{}{}
-		defer func() {
-			if !nodes[n].owned {
-				cost -= nodes[n].contributionPoints
-			}
-			delete(visited, n)
-		}()
-		deadend := true
-		for n2 := range connections[n] {
-			if _, ok := deadends[n2]; ok {
-				continue
-			}
-			if _, ok := visited[n2]; ok {
-				continue
-			}
-			if n2 == nodeB || (nodeB == "" && nodes[n2].owned) {
-				deadend = false
-				npth := make([]string, len(pth)+2)
-				copy(npth, pth)
-				npth[len(pth)] = n
-				npth[len(pth)+1] = n2
-				ncost := cost
-				if !nodes[n2].owned {
-					ncost += nodes[n2].contributionPoints
-				}
-				if bestCost > ncost {
-					bestCost = ncost
-					bestPaths = bestPaths[:0]
-					bestPaths = append(bestPaths, npth)
-				} else if bestCost == ncost {
-					bestPaths = append(bestPaths, npth)
-				}
-				continue
-			}
-			npth := make([]string, len(pth)+1)
-			copy(npth, pth)
-			npth[len(pth)] = n
-			if branch(npth, n2) {
-				deadend = false
+// printEstimates prints the estimated output of the working nodes for the
+// default nodes report, and which nodes could not be estimated.
+func printEstimates(estimates []*bdo.Estimate, unestimated []string) {
+	if len(estimates) > 0 {
+		fmt.Printf("\nEstimated output per real-time hour:\n")
+		assumed := false
+		for _, e := range estimates {
+			mark := ""
+			if e.Assumed {
+				mark = "*"
+				assumed = true
 			}
+			fmt.Printf("    %s: %.1f cycles, %.1f items, %s%s from %s, %.1f hours of stamina\n", e.Node, e.CyclesPerHour, e.ItemsPerHour, e.Worker.Type, mark, e.Town, e.StaminaHours)
 		}
-		if deadend {
-			deadends[n] = struct{}{}
-			return false
+		if assumed {
+			fmt.Printf("    * worker type not given, so a new %s was assumed\n", bdo.DefaultWorkerType)
 		}
-		return true
 	}
-	branch(nil, nodeA)
-	return bestCost, bestPaths
+	if len(unestimated) > 0 {
+		fmt.Printf("\nNo workload or distance is known to estimate, from the workloads file or the node data: %s\n", strings.Join(unestimated, ", "))
+	}
 }
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gholt/bdot/bdo"
+	"github.com/gholt/bdot/table"
+)
+
+// outputFormat is set with the --format option and is one of "text", "json",
+// or "csv".
+var outputFormat = "text"
+
+func printJSON(v interface{}) {
+	b, err := json.MarshalIndent(v, "", "    ")
+	errnil(err)
+	fmt.Println(string(b))
+}
+
+func printCSV(rows [][]string) {
+	w := csv.NewWriter(os.Stdout)
+	errnil(w.WriteAll(rows))
+}
+
+// printTable prints a table report, a header row followed by data rows, with
+// nil rows being separators.
+func printTable(report [][]string) {
+	var header []string
+	rows := [][]string{}
+	for _, row := range report {
+		if row == nil {
+			continue
+		}
+		if header == nil {
+			header = row
+		} else {
+			rows = append(rows, row)
+		}
+	}
+	switch outputFormat {
+	case "json":
+		printJSON(struct {
+			Header []string   `json:"header"`
+			Rows   [][]string `json:"rows"`
+		}{header, rows})
+	case "csv":
+		printCSV(append([][]string{header}, rows...))
+	default:
+		fmt.Print(alignTable(report))
+	}
+}
+
+// alignTable returns the report in the table file format, with the cells
+// escaped so the table package reads them back the same.
+func alignTable(report [][]string) string {
+	escaped := make([][]string, len(report))
+	for i, row := range report {
+		if row == nil {
+			continue
+		}
+		escaped[i] = make([]string, len(row))
+		for j, cell := range row {
+			escaped[i][j] = table.Escape(cell)
+		}
+	}
+	var widths []int
+	for _, row := range escaped {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := displayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	var b strings.Builder
+	separator := func() {
+		b.WriteByte('+')
+		for _, w := range widths {
+			b.WriteString(strings.Repeat("-", w+2))
+			b.WriteByte('+')
+		}
+		b.WriteByte('\n')
+	}
+	separator()
+	for _, row := range escaped {
+		if row == nil {
+			separator()
+			continue
+		}
+		b.WriteByte('|')
+		for i, w := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			b.WriteString(" " + cell + strings.Repeat(" ", w-displayWidth(cell)) + " |")
+		}
+		b.WriteByte('\n')
+	}
+	separator()
+	return b.String()
+}
+
+// displayWidth returns the number of characters s shows as, not counting
+// terminal escape sequences such as those tableHighlight adds.
+func displayWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[' {
+			i += 2
+			for i < len(s) && (s[i] < '@' || s[i] > '~') {
+				i++
+			}
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		width++
+		i += size
+	}
+	return width
+}
+
+var nodeCSVHeader = []string{"Name", "CP", "Owned", "Closest Worker", "Assigned Worker", "Produces"}
+
+func nodeCSVRow(n *bdo.Node) []string {
+	return []string{n.Name, strconv.Itoa(n.ContributionPoints), strconv.FormatBool(n.Owned), n.ClosestWorker, n.AssignedWorker, strings.Join(n.Produces, ", ")}
+}
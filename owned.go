@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gholt/bdot/bdo"
+)
+
+// saveOwned rewrites the "owned" file from the nodes owned in g.
+func saveOwned(g *bdo.Graph) error {
+	var b bytes.Buffer
+	if err := g.WriteOwned(&b); err != nil {
+		return err
+	}
+	return writeFileAtomic("owned", b.Bytes())
+}
+
+// writeFileAtomic replaces the named file with data by writing a temporary
+// file beside it and renaming it into place, so the file is never left half
+// written.
+func writeFileAtomic(filename string, data []byte) error {
+	mode := os.FileMode(0644)
+	if fi, err := os.Stat(filename); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	f, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(data); err == nil {
+		err = f.Chmod(mode)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filename)
+}
+
+// readOwnedLines returns the lines of the "owned" file, or none if there is
+// no such file yet.
+func readOwnedLines() []string {
+	b, err := os.ReadFile("owned")
+	if os.IsNotExist(err) {
+		return nil
+	}
+	errnil(err)
+	s := strings.TrimSuffix(string(b), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func writeOwnedLines(lines []string) {
+	var b bytes.Buffer
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	errnil(writeFileAtomic("owned", b.Bytes()))
+}
+
+// confirm asks the question on stderr, so it stays out of any JSON or CSV
+// output, and returns true if the answer read from stdin, or from the shell's
+// input when in the shell, starts with a y.
+func confirm(question string) bool {
+	question += " [y/N] "
+	var answer string
+	if shellAsk != nil {
+		answer, _ = shellAsk(question)
+	} else {
+		fmt.Fprint(os.Stderr, question)
+		answer, _ = bufio.NewReader(os.Stdin).ReadString('\n')
+	}
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(answer)), "y")
+}
+
+func nodesBuy(g *bdo.Graph, args []string) {
+	withPath := false
+	var names []string
+	for _, arg := range args {
+		if arg == "--with-path" {
+			withPath = true
+		} else {
+			names = append(names, arg)
+		}
+	}
+	if len(names) != 1 {
+		help("buy needs one <node>", 1)
+	}
+	n := g.Node(findNode(g, names[0]))
+	if n.Owned {
+		help(fmt.Sprintf("%s is already owned.", n.Name), 1)
+	}
+	buy := []string{n.Name}
+	if withPath {
+		_, pths := g.BestPaths(n.Name, "")
+		if len(pths) == 0 {
+			help(fmt.Sprintf("Could not find a way to connect %s to your network.", n.Name), 1)
+		}
+		buy = buy[:0]
+		for i := len(pths[0]) - 1; i >= 0; i-- {
+			if !g.Node(pths[0][i]).Owned {
+				buy = append(buy, pths[0][i])
+			}
+		}
+	} else {
+		connected := false
+		for _, c := range g.Connections(n.Name) {
+			if g.Node(c).Owned {
+				connected = true
+				break
+			}
+		}
+		if !connected {
+			help(fmt.Sprintf("%s is not next to any node you own; use --with-path to buy the nodes connecting it as well.", n.Name), 1)
+		}
+	}
+	lines := readOwnedLines()
+	cp := 0
+	for _, b := range buy {
+		lines = append(lines, b)
+		cp += g.Node(b).ContributionPoints
+	}
+	writeOwnedLines(lines)
+	for _, b := range buy {
+		g.Node(b).Owned = true
+	}
+	if outputFormat != "text" {
+		printOwnedChange(g, buy, cp)
+		return
+	}
+	for _, b := range buy {
+		fmt.Printf("   %2d for %s\n", g.Node(b).ContributionPoints, b)
+	}
+	fmt.Printf("Bought %d nodes for %d contribution points.\n", len(buy), cp)
+}
+
+func nodesSell(g *bdo.Graph, args []string) {
+	cascade := false
+	var names []string
+	for _, arg := range args {
+		if arg == "--cascade" {
+			cascade = true
+		} else {
+			names = append(names, arg)
+		}
+	}
+	if len(names) != 1 {
+		help("sell needs one <node>", 1)
+	}
+	n := g.Node(findNode(g, names[0]))
+	if n.ContributionPoints == 0 {
+		help(fmt.Sprintf("%s is always owned.", n.Name), 1)
+	}
+	if !n.Owned {
+		help(fmt.Sprintf("%s is not owned.", n.Name), 1)
+	}
+	sell := append([]string{n.Name}, g.Cutoff(n.Name)...)
+	if len(sell) > 1 || n.AssignedWorker != "" {
+		fmt.Fprintf(os.Stderr, "Selling %s would lose:\n", n.Name)
+		for _, s := range sell {
+			if w := g.Node(s).AssignedWorker; w != "" {
+				fmt.Fprintf(os.Stderr, "    %s, worked by a worker from %s\n", s, w)
+			} else {
+				fmt.Fprintf(os.Stderr, "    %s\n", s)
+			}
+		}
+		if !cascade {
+			help("Nothing was sold; use --cascade to sell all of these.", 1)
+		}
+		if !confirm(fmt.Sprintf("Sell all %d nodes?", len(sell))) {
+			fmt.Fprintln(os.Stderr, "Nothing was sold.")
+			return
+		}
+	}
+	selling := map[string]struct{}{}
+	for _, s := range sell {
+		selling[s] = struct{}{}
+	}
+	var lines []string
+	for _, line := range readOwnedLines() {
+		if o, err := g.Resolve(strings.SplitN(line, " -- ", 2)[0]); err == nil {
+			if _, ok := selling[o.Name]; ok {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	writeOwnedLines(lines)
+	cp := 0
+	for _, s := range sell {
+		g.Node(s).Owned = false
+		g.Node(s).AssignedWorker = ""
+		g.Node(s).Worker = nil
+		cp += g.Node(s).ContributionPoints
+	}
+	if outputFormat != "text" {
+		printOwnedChange(g, sell, cp)
+		return
+	}
+	fmt.Printf("Sold %d nodes for %d contribution points.\n", len(sell), cp)
+}
+
+// printOwnedChange prints the nodes bought or sold, and their total
+// contribution points, as JSON or CSV.
+func printOwnedChange(g *bdo.Graph, names []string, cp int) {
+	nodes := []*bdo.Node{}
+	rows := [][]string{nodeCSVHeader}
+	for _, name := range names {
+		nodes = append(nodes, g.Node(name))
+		rows = append(rows, nodeCSVRow(g.Node(name)))
+	}
+	if outputFormat == "csv" {
+		printCSV(rows)
+		return
+	}
+	printJSON(struct {
+		Cost  int         `json:"cost"`
+		Nodes []*bdo.Node `json:"nodes"`
+	}{cp, nodes})
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gholt/bdot/bdo"
+)
+
+func planCommand(g *bdo.Graph, args []string) {
+	var cmd string
+	if len(args) > 0 {
+		cmd = args[0]
+		args = args[1:]
+	}
+	switch cmd {
+	case "goal":
+		planGoal(g, args)
+	default:
+		help(fmt.Sprintf("Unknown plan command %q.", cmd), 1)
+	}
+}
+
+func planGoal(g *bdo.Graph, args []string) {
+	if len(args) < 2 {
+		help("plan goal needs an <item> and a <qty/day>", 1)
+	}
+	qty := strings.TrimSuffix(args[len(args)-1], "/day")
+	perDay, err := strconv.ParseFloat(qty, 64)
+	if err != nil || perDay <= 0 {
+		help(fmt.Sprintf("Invalid quantity per day %q.", args[len(args)-1]), 1)
+	}
+	rs := loadRecipes()
+	item, err := rs.ResolveItem(g, strings.Join(args[:len(args)-1], " "))
+	if err != nil {
+		help(err.Error(), 1)
+	}
+	need := map[string]float64{}
+	bought := map[string]float64{}
+	for raw, count := range rs.Tree(item, perDay).Raw() {
+		if rs.Bought(raw) {
+			bought[raw] = count
+		} else {
+			need[raw] = count
+		}
+	}
+	shortfalls, plan := g.Goal(need)
+	if outputFormat == "json" {
+		if plan == nil {
+			plan = []*bdo.Purchase{}
+		}
+		printJSON(struct {
+			Item       string             `json:"item"`
+			PerDay     float64            `json:"perDay"`
+			Shortfalls []*bdo.Shortfall   `json:"shortfalls"`
+			Bought     map[string]float64 `json:"bought"`
+			Purchases  []*bdo.Purchase    `json:"purchases"`
+		}{item, perDay, shortfalls, bought, plan})
+		return
+	}
+	if outputFormat == "csv" {
+		rows := [][]string{{"For", "Buy", "CP"}}
+		for _, p := range plan {
+			for _, n := range p.Buy {
+				rows = append(rows, []string{p.Node, n, strconv.Itoa(g.Node(n).ContributionPoints)})
+			}
+		}
+		printCSV(rows)
+		return
+	}
+	fmt.Printf("For %s %s per day you need each day:\n", formatCount(perDay), item)
+	report := [][]string{{"Item", "Need", "Have", "Planned", "Short"}, nil}
+	var unestimated []string
+	var short []string
+	seen := map[string]struct{}{}
+	for _, s := range shortfalls {
+		if s.Short > 0 {
+			short = append(short, s.Item)
+		}
+		report = append(report, []string{s.Item, formatCount(s.Need), formatCount(s.Have), formatCount(s.Planned), formatCount(s.Short)})
+		for _, n := range s.Unestimated {
+			if _, ok := seen[n]; !ok {
+				seen[n] = struct{}{}
+				unestimated = append(unestimated, n)
+			}
+		}
+	}
+	printTable(report)
+	if len(bought) > 0 {
+		var items []string
+		for item := range bought {
+			items = append(items, item)
+		}
+		sort.Strings(items)
+		for i, item := range items {
+			items[i] = fmt.Sprintf("%s %s", formatCount(bought[item]), item)
+		}
+		fmt.Printf("Also bought from vendors each day: %s.\n", strings.Join(items, ", "))
+	}
+	if len(short) > 0 {
+		fmt.Printf("Not enough nodes with estimated output can be connected to make up the need for: %s.\n", strings.Join(short, ", "))
+	}
+	if recipesFileName() == "" {
+		fmt.Println(builtInRecipesNote)
+	}
+	if len(unestimated) > 0 {
+		sort.Strings(unestimated)
+		fmt.Printf("No workload or distance is known to estimate these, so they are not counted: %s\n", strings.Join(unestimated, ", "))
+	}
+	if len(plan) == 0 {
+		return
+	}
+	cost := 0
+	for _, p := range plan {
+		cost += p.Cost
+	}
+	fmt.Printf("\nSpend %d contribution points, cheapest first:\n", cost)
+	for _, p := range plan {
+		fmt.Printf("For %s (%s per day of %s):\n", p.Node, formatCount(p.Value), strings.Join(p.Items, ", "))
+		for _, n := range p.Buy {
+			fmt.Printf("   %2d for %s\n", g.Node(n).ContributionPoints, n)
+		}
+	}
+}
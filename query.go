@@ -0,0 +1,411 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func tableQuery(args []string) {
+	if len(args) < 2 {
+		help("table query needs a <file> and <query>", 1)
+	}
+	header, data := tableRead(args[0])
+	q, err := parseQuery(header, strings.Join(args[1:], " "))
+	errnil(err)
+	rows := q.rows(data)
+	project := func(row []string) []string {
+		if q.columns == nil {
+			return row
+		}
+		projected := make([]string, len(q.columns))
+		for i, c := range q.columns {
+			projected[i] = row[c]
+		}
+		return projected
+	}
+	report := [][]string{project(header), nil}
+	for _, row := range rows {
+		report = append(report, project(row))
+	}
+	printTable(report)
+}
+
+// query is a parsed table query:
+//
+//	[select <column>, ...] [[where] <condition>]
+//	[order by <column> [asc|desc], ...] [limit <n>]
+//
+// Conditions are "<column> <op> <value>" joined with and, or, not, and
+// parentheses, where <op> is one of = != < <= > >= for comparisons, numeric
+// if both sides are numbers, or ~ and !~ for regular expression matches.
+// Keywords, column names, and comparisons of words ignore case. Names and
+// values may be several words, kept with the spacing given, and need double
+// quotes to include keywords, operators, or parentheses, such as a regular
+// expression with a group; "" is an empty value.
+// Commas only separate the select and order by columns, so values such as
+// 1,500 need no quotes.
+type query struct {
+	columns []int
+	where   queryExpr
+	orderBy []queryOrder
+	limit   int
+}
+
+// rows returns the data rows matching the query, in its order and up to its
+// limit.
+func (q *query) rows(data [][]string) [][]string {
+	var rows [][]string
+	for _, row := range data {
+		if q.where == nil || q.where.match(row) {
+			rows = append(rows, row)
+		}
+	}
+	if len(q.orderBy) > 0 {
+		sort.SliceStable(rows, func(i, j int) bool {
+			for _, o := range q.orderBy {
+				if c := queryCompare(rows[i][o.column], rows[j][o.column]); c != 0 {
+					return (c < 0) != o.descending
+				}
+			}
+			return false
+		})
+	}
+	if q.limit >= 0 && len(rows) > q.limit {
+		rows = rows[:q.limit]
+	}
+	return rows
+}
+
+type queryOrder struct {
+	column     int
+	descending bool
+}
+
+type queryExpr interface {
+	match(row []string) bool
+}
+
+type queryAnd struct{ a, b queryExpr }
+
+func (q *queryAnd) match(row []string) bool { return q.a.match(row) && q.b.match(row) }
+
+type queryOr struct{ a, b queryExpr }
+
+func (q *queryOr) match(row []string) bool { return q.a.match(row) || q.b.match(row) }
+
+type queryNot struct{ a queryExpr }
+
+func (q *queryNot) match(row []string) bool { return !q.a.match(row) }
+
+type queryCondition struct {
+	column int
+	op     string
+	value  string
+	re     *regexp.Regexp
+}
+
+func (q *queryCondition) match(row []string) bool {
+	v := row[q.column]
+	switch q.op {
+	case "~":
+		return q.re.MatchString(v)
+	case "!~":
+		return !q.re.MatchString(v)
+	}
+	c := queryCompare(v, q.value)
+	switch q.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// queryCompare compares a and b as numbers if both are, ignoring commas, or
+// otherwise as words ignoring case; numbers sort before words.
+func queryCompare(a string, b string) int {
+	na, aok := queryNumber(a)
+	nb, bok := queryNumber(b)
+	switch {
+	case aok && bok:
+		if na < nb {
+			return -1
+		}
+		if na > nb {
+			return 1
+		}
+		return 0
+	case aok:
+		return -1
+	case bok:
+		return 1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func queryNumber(s string) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+	return n, err == nil
+}
+
+type queryToken struct {
+	text string
+	// quoted tokens are never keywords or operators.
+	quoted bool
+	// space is the spacing before the token, which words keeps.
+	space string
+}
+
+var queryOperators = []string{"!=", "!~", "<=", ">=", "=", "<", ">", "~", "(", ")"}
+
+func queryTokens(s string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(s); {
+		space := ""
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+			space += s[i : i+1]
+			i++
+		}
+		if i == len(s) {
+			break
+		}
+		switch c := s[i]; {
+		case c == '"':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("Unterminated quote in query at %q", s[i:])
+			}
+			tokens = append(tokens, queryToken{text: s[i+1 : i+1+end], quoted: true, space: space})
+			i += end + 2
+		case c == ',':
+			tokens = append(tokens, queryToken{text: ",", space: space})
+			i++
+		case queryOperator(s[i:]) != "":
+			op := queryOperator(s[i:])
+			tokens = append(tokens, queryToken{text: op, space: space})
+			i += len(op)
+		default:
+			end := i + 1
+			for end < len(s) && !strings.ContainsRune(" \t\",", rune(s[end])) && queryOperator(s[end:]) == "" {
+				end++
+			}
+			tokens = append(tokens, queryToken{text: s[i:end], space: space})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// queryOperator returns the operator s starts with, if any.
+func queryOperator(s string) string {
+	for _, o := range queryOperators {
+		if strings.HasPrefix(s, o) {
+			return o
+		}
+	}
+	return ""
+}
+
+type queryParser struct {
+	header []string
+	tokens []queryToken
+	pos    int
+}
+
+// is returns true if the next token is one of the keywords or operators.
+func (p *queryParser) is(words ...string) bool {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(p.tokens[p.pos].text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// words returns the words up to the next of the keywords or any operator,
+// with the spacing between them as given.
+func (p *queryParser) words(keywords ...string) string {
+	var words strings.Builder
+	for start := p.pos; p.pos < len(p.tokens) && !p.is(keywords...) && !p.is(queryOperators...); p.pos++ {
+		if p.pos > start {
+			words.WriteString(p.tokens[p.pos].space)
+		}
+		words.WriteString(p.tokens[p.pos].text)
+	}
+	return words.String()
+}
+
+func (p *queryParser) column(name string) (int, error) {
+	if name == "" {
+		return 0, fmt.Errorf("Expected a column name in query")
+	}
+	for i, h := range p.header {
+		if strings.EqualFold(h, name) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("Could not find column %q; the columns are %s", name, strings.Join(p.header, ", "))
+}
+
+func parseQuery(header []string, s string) (*query, error) {
+	tokens, err := queryTokens(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{header: header, tokens: tokens}
+	q := &query{limit: -1}
+	if p.is("select") {
+		p.pos++
+		if p.is("*") {
+			p.pos++
+		} else {
+			for {
+				c, err := p.column(p.words(",", "where", "order", "limit"))
+				if err != nil {
+					return nil, err
+				}
+				q.columns = append(q.columns, c)
+				if !p.is(",") {
+					break
+				}
+				p.pos++
+			}
+		}
+	}
+	if p.is("where") {
+		p.pos++
+	}
+	if p.pos < len(p.tokens) && !p.is("order", "limit") {
+		if q.where, err = p.or(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("order") {
+		p.pos++
+		if !p.is("by") {
+			return nil, fmt.Errorf("Expected by after order in query")
+		}
+		p.pos++
+		for {
+			c, err := p.column(p.words(",", "asc", "desc", "limit"))
+			if err != nil {
+				return nil, err
+			}
+			o := queryOrder{column: c}
+			if p.is("asc", "desc") {
+				o.descending = p.is("desc")
+				p.pos++
+			}
+			q.orderBy = append(q.orderBy, o)
+			if !p.is(",") {
+				break
+			}
+			p.pos++
+		}
+	}
+	if p.is("limit") {
+		p.pos++
+		if p.pos >= len(p.tokens) {
+			return nil, fmt.Errorf("Expected a number after limit in query")
+		}
+		n, err := strconv.Atoi(p.tokens[p.pos].text)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("Expected a number after limit in query, not %q", p.tokens[p.pos].text)
+		}
+		q.limit = n
+		p.pos++
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("Unexpected %q in query", p.tokens[p.pos].text)
+	}
+	return q, nil
+}
+
+func (p *queryParser) or() (queryExpr, error) {
+	a, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("or") {
+		p.pos++
+		b, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		a = &queryOr{a, b}
+	}
+	return a, nil
+}
+
+func (p *queryParser) and() (queryExpr, error) {
+	a, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("and") {
+		p.pos++
+		b, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		a = &queryAnd{a, b}
+	}
+	return a, nil
+}
+
+func (p *queryParser) not() (queryExpr, error) {
+	if p.is("not") {
+		p.pos++
+		a, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return &queryNot{a}, nil
+	}
+	if p.is("(") {
+		p.pos++
+		a, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.is(")") {
+			return nil, fmt.Errorf("Expected ) in query")
+		}
+		p.pos++
+		return a, nil
+	}
+	column, err := p.column(p.words())
+	if err != nil {
+		return nil, err
+	}
+	if !p.is("=", "!=", "<", "<=", ">", ">=", "~", "!~") {
+		return nil, fmt.Errorf("Expected a comparison after %q in query", p.header[column])
+	}
+	c := &queryCondition{column: column, op: p.tokens[p.pos].text}
+	p.pos++
+	start := p.pos
+	c.value = p.words("and", "or", "order", "limit")
+	if p.pos == start {
+		return nil, fmt.Errorf("Expected a value after %s %s in query", p.header[column], c.op)
+	}
+	if c.op == "~" || c.op == "!~" {
+		if c.re, err = regexp.Compile("(?i)" + c.value); err != nil {
+			return nil, fmt.Errorf("Invalid regular expression %q in query: %s", c.value, err)
+		}
+	}
+	return c, nil
+}
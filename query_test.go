@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var queryTestHeader = []string{"Item", "Price", "Region"}
+
+var queryTestData = [][]string{
+	{"Oze's Bread", "1,500", "Balenos"},
+	{"Beer", "2,000", "Serendia"},
+	{"Milk", "900", "Balenos"},
+	{"Corn  Flour", "120", ""},
+	{"Grape (Fresh)", "300", "Calpheon"},
+}
+
+// queryItems returns the Item of each row the query gives, in order.
+func queryItems(t *testing.T, s string) []string {
+	t.Helper()
+	q, err := parseQuery(queryTestHeader, s)
+	if err != nil {
+		t.Fatalf("%q: %s", s, err)
+	}
+	var items []string
+	for _, row := range q.rows(queryTestData) {
+		items = append(items, row[0])
+	}
+	return items
+}
+
+func TestQuery(t *testing.T) {
+	for s, want := range map[string][]string{
+		"Item = Oze's Bread":                                {"Oze's Bread"},
+		"item = beer":                                       {"Beer"},
+		"Price > 1,500":                                     {"Beer"},
+		"Price >= 1,500 order by Price":                     {"Oze's Bread", "Beer"},
+		`Region = ""`:                                       {"Corn  Flour"},
+		"Item = Corn  Flour":                                {"Corn  Flour"},
+		"Item = Corn Flour":                                 nil,
+		`Item = "Grape (Fresh)"`:                            {"Grape (Fresh)"},
+		`Item ~ "^gr.*\(fresh\)$"`:                          {"Grape (Fresh)"},
+		"Item !~ e":                                         {"Milk", "Corn  Flour"},
+		"Region = Balenos and not Price < 1000":             {"Oze's Bread"},
+		"not (Region = Balenos or Region = Serendia)":       {"Corn  Flour", "Grape (Fresh)"},
+		"Region = Balenos or Price > 1000 and Price < 2000": {"Oze's Bread", "Milk"},
+		"order by Region desc, Price limit 3":               {"Beer", "Grape (Fresh)", "Milk"},
+		"where Price < 1000 order by Item limit 2":          {"Corn  Flour", "Grape (Fresh)"},
+		"limit 0": nil,
+	} {
+		if got := queryItems(t, s); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q gave %q, not %q", s, got, want)
+		}
+	}
+}
+
+func TestQuerySelect(t *testing.T) {
+	for s, want := range map[string][]int{
+		"select Region, Item":               {2, 0},
+		"select Price,Item where Price > 0": {1, 0},
+		"select *":                          nil,
+	} {
+		q, err := parseQuery(queryTestHeader, s)
+		if err != nil {
+			t.Errorf("%q: %s", s, err)
+		} else if !reflect.DeepEqual(q.columns, want) {
+			t.Errorf("%q selected %v, not %v", s, q.columns, want)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	for s, want := range map[string]string{
+		`Item = "Beer`:        "Unterminated quote",
+		"Item =":              "Expected a value",
+		"Cost > 5":            `Could not find column "Cost"`,
+		"Item":                "Expected a comparison",
+		"(Item = Beer":        "Expected )",
+		"order Price":         "Expected by",
+		"limit many":          "Expected a number",
+		`Item ~ "("`:          "Invalid regular expression",
+		"select Item, Price,": "Expected a column name",
+	} {
+		if _, err := parseQuery(queryTestHeader, s); err == nil {
+			t.Errorf("%q parsed without error", s)
+		} else if !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: %s, not %s", s, err, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gholt/bdot/bdo"
+)
+
+// recipesFileName returns the --recipes file, or the file named by the
+// BDOT_RECIPES environment variable, or "" to use the built in data.
+func recipesFileName() string {
+	if recipesFile != "" {
+		return recipesFile
+	}
+	return os.Getenv("BDOT_RECIPES")
+}
+
+// builtInRecipesNote is shown with output that rests on the built in recipe
+// data.
+const builtInRecipesNote = "The built in recipe quantities are placeholders, not checked against the game; give your own with --recipes."
+
+func loadRecipes() *bdo.Recipes {
+	var rs *bdo.Recipes
+	var err error
+	if recipesFile := recipesFileName(); recipesFile == "" {
+		rs, err = bdo.DefaultRecipes()
+	} else {
+		rs, err = bdo.LoadRecipesFile(recipesFile)
+	}
+	errnil(err)
+	return rs
+}
+
+func recipesCommand(g *bdo.Graph, args []string) {
+	var cmd string
+	if len(args) > 0 {
+		cmd = args[0]
+		args = args[1:]
+	}
+	switch cmd {
+	case "tree":
+		if len(args) < 1 {
+			help("recipes tree needs an <item>", 1)
+		}
+		rs := loadRecipes()
+		name, err := rs.Resolve(strings.Join(args, " "))
+		if err != nil {
+			help(err.Error(), 1)
+		}
+		recipesTree(g, rs, rs.Tree(name, 1))
+	default:
+		help(fmt.Sprintf("Unknown recipes command %q.", cmd), 1)
+	}
+}
+
+// recipeSource returns how a raw item is had: "bought", "supplied" with the
+// owned and worked nodes producing it, or "missing".
+func recipeSource(g *bdo.Graph, rs *bdo.Recipes, item string) (string, []string) {
+	if rs.Bought(item) {
+		return "bought", nil
+	}
+	var names []string
+	for _, n := range g.Supplying(item) {
+		names = append(names, n.Name)
+	}
+	if len(names) > 0 {
+		return "supplied", names
+	}
+	return "missing", nil
+}
+
+func recipesTree(g *bdo.Graph, rs *bdo.Recipes, tree *bdo.RecipeTree) {
+	var missing []string
+	seen := map[string]struct{}{}
+	if outputFormat == "json" {
+		type treeJSON struct {
+			*bdo.RecipeTree
+			Source      string      `json:"source,omitempty"`
+			SuppliedBy  []string    `json:"suppliedBy,omitempty"`
+			Ingredients []*treeJSON `json:"ingredients,omitempty"`
+		}
+		var convert func(t *bdo.RecipeTree) *treeJSON
+		convert = func(t *bdo.RecipeTree) *treeJSON {
+			j := &treeJSON{RecipeTree: t}
+			if t.Recipe == nil {
+				j.Source, j.SuppliedBy = recipeSource(g, rs, t.Item)
+			}
+			for _, in := range t.Ingredients {
+				j.Ingredients = append(j.Ingredients, convert(in))
+			}
+			return j
+		}
+		printJSON(convert(tree))
+		return
+	}
+	report := [][]string{{"Item", "Count", "Source", "Supplied By"}, nil}
+	if outputFormat == "csv" {
+		report[0] = append([]string{"Level"}, report[0]...)
+	}
+	var walk func(t *bdo.RecipeTree, level int)
+	walk = func(t *bdo.RecipeTree, level int) {
+		source := t.Kind
+		var by []string
+		if t.Recipe == nil {
+			source, by = recipeSource(g, rs, t.Item)
+			if _, ok := seen[t.Item]; source == "missing" && !ok {
+				missing = append(missing, t.Item)
+				seen[t.Item] = struct{}{}
+			}
+		}
+		row := []string{strings.Repeat("  ", level) + t.Item, formatCount(t.Count), source, strings.Join(by, ", ")}
+		if outputFormat == "csv" {
+			row = []string{strconv.Itoa(level), t.Item, formatCount(t.Count), source, strings.Join(by, ", ")}
+		}
+		report = append(report, row)
+		for _, in := range t.Ingredients {
+			walk(in, level+1)
+		}
+	}
+	walk(tree, 0)
+	printTable(report)
+	if outputFormat == "text" && len(missing) > 0 {
+		fmt.Printf("Missing raw items: %s.\n", strings.Join(missing, ", "))
+	}
+	if outputFormat == "text" && recipesFileName() == "" {
+		fmt.Println(builtInRecipesNote)
+	}
+}
+
+// formatCount returns the count rounded to two decimal places, without
+// trailing zeros.
+func formatCount(count float64) string {
+	return strconv.FormatFloat(math.Round(count*100)/100, 'f', -1, 64)
+}
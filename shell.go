@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gholt/bdot/bdo"
+	"golang.org/x/term"
+)
+
+// inShell is set while the shell is running so that help and errnil report
+// errors back to the shell with a shellError panic rather than exiting.
+var inShell bool
+
+// shellAsk is set while the shell is running to read the answer to a
+// question from the shell's own input, since it owns stdin.
+var shellAsk func(question string) (string, error)
+
+type shellError string
+
+var shellCommands = []string{"exit", "format", "help", "history", "items", "nodes", "own", "plan", "quit", "recipes", "save", "table", "trade", "unown", "worker"}
+
+var shellSubcommands = map[string][]string{
+	"items":   {"list", "where"},
+	"nodes":   {"assign", "buy", "connect-all", "graph", "path", "plan", "rank", "search", "sell"},
+	"plan":    {"goal"},
+	"recipes": {"tree"},
+	"table":   {"add", "delete", "join", "query", "search", "search-column", "set", "validate"},
+	"trade":   {"route"},
+	"format":  {"csv", "json", "text"},
+}
+
+func shell(args []string) {
+	if len(args) > 0 {
+		help("shell takes no arguments", 1)
+	}
+	g := loadGraph()
+	var names []string
+	for _, n := range g.Nodes() {
+		names = append(names, n.Name)
+	}
+	items := map[string]struct{}{}
+	for _, item := range g.Items() {
+		items[item.Name] = struct{}{}
+	}
+	for _, name := range loadRecipes().Names() {
+		items[name] = struct{}{}
+	}
+	for item := range items {
+		names = append(names, item)
+	}
+	sort.Strings(names)
+	var history []string
+	inShell = true
+	defer func() {
+		inShell = false
+		shellAsk = nil
+	}()
+	fd := int(os.Stdin.Fd())
+	interactive := term.IsTerminal(fd)
+	var readLine func() (string, error)
+	if interactive {
+		t := term.NewTerminal(struct {
+			io.Reader
+			io.Writer
+		}{os.Stdin, os.Stdout}, "bdot> ")
+		t.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+			if key != '\t' {
+				return "", 0, false
+			}
+			return shellComplete(line, pos, names)
+		}
+		// The terminal is only raw while reading so that command output,
+		// which is written straight to stdout, is not mangled.
+		readLine = func() (string, error) {
+			state, err := term.MakeRaw(fd)
+			if err != nil {
+				return "", err
+			}
+			defer term.Restore(fd, state)
+			return t.ReadLine()
+		}
+		shellAsk = func(question string) (string, error) {
+			t.SetPrompt(question)
+			defer t.SetPrompt("bdot> ")
+			return readLine()
+		}
+	} else {
+		scanner := bufio.NewScanner(os.Stdin)
+		readLine = func() (string, error) {
+			if !scanner.Scan() {
+				if err := scanner.Err(); err != nil {
+					return "", err
+				}
+				return "", io.EOF
+			}
+			return scanner.Text(), nil
+		}
+		shellAsk = func(question string) (string, error) {
+			fmt.Fprint(os.Stderr, question)
+			return readLine()
+		}
+	}
+	for {
+		line, err := readLine()
+		if err == io.EOF {
+			if interactive {
+				fmt.Println()
+			}
+			return
+		}
+		errnil(err)
+		args := shellSplit(line)
+		if len(args) == 0 {
+			continue
+		}
+		history = append(history, line)
+		if args[0] == "exit" || args[0] == "quit" {
+			return
+		}
+		shellRun(g, args, history)
+	}
+}
+
+// shellRun runs a single shell command, reporting any error from help or
+// errnil rather than letting it end the shell.
+func shellRun(g *bdo.Graph, args []string, history []string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg, ok := r.(shellError)
+			if !ok {
+				panic(r)
+			}
+			if msg == "" {
+				usage()
+			} else {
+				fmt.Fprintln(os.Stderr, msg)
+			}
+		}
+	}()
+	switch args[0] {
+	case "nodes":
+		nodesCommand(g, args[1:])
+	case "items":
+		itemsCommand(g, args[1:])
+	case "plan":
+		planCommand(g, args[1:])
+	case "recipes":
+		recipesCommand(g, args[1:])
+	case "trade":
+		tradeCommand(g, args[1:])
+	case "table":
+		tableCommand(args[1:])
+	case "own", "unown":
+		if len(args) != 2 {
+			help(fmt.Sprintf("%s needs a <node>", args[0]), 1)
+		}
+		n := g.Node(findNode(g, args[1]))
+		if n.ContributionPoints == 0 {
+			help(fmt.Sprintf("%s is always owned.", n.Name), 1)
+		}
+		n.Owned = args[0] == "own"
+		if !n.Owned {
+			n.AssignedWorker = ""
+			n.Worker = nil
+		}
+	case "worker":
+		if len(args) < 2 {
+			help("worker needs a <node> and optionally a [worker city] and [worker type and stats]", 1)
+		}
+		n := g.Node(findNode(g, args[1]))
+		if !n.Owned {
+			help(fmt.Sprintf("%s is not owned.", n.Name), 1)
+		}
+		n.AssignedWorker = ""
+		n.Worker = nil
+		if len(args) > 2 {
+			n.AssignedWorker = findNode(g, args[2])
+		}
+		if len(args) > 3 {
+			w, err := bdo.ParseWorker(strings.Join(args[3:], " "))
+			errnil(err)
+			n.Worker = w
+		}
+	case "save":
+		errnil(saveOwned(g))
+	case "format":
+		if len(args) != 2 {
+			help("format needs one of text, json, or csv", 1)
+		}
+		switch args[1] {
+		case "text", "json", "csv":
+			outputFormat = args[1]
+		default:
+			help(fmt.Sprintf("Unknown format %q.", args[1]), 1)
+		}
+	case "history":
+		for i, line := range history {
+			fmt.Printf("%4d  %s\n", i+1, line)
+		}
+	case "help":
+		usage()
+	default:
+		help(fmt.Sprintf("Unknown command %q.", args[0]), 1)
+	}
+}
+
+// shellSplit splits a shell line into arguments on spaces, keeping spaces
+// within double quotes. Single quotes are left alone since they are common in
+// node names.
+func shellSplit(line string) []string {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	quoted := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inArg = true
+		case r == ' ' && !quoted:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
+
+// shellComplete completes the word before pos in line with a command name or,
+// after the command, a node or item name, quoting it if it has spaces.
+func shellComplete(line string, pos int, names []string) (string, int, bool) {
+	head := line[:pos]
+	quoted := strings.Count(head, `"`)%2 == 1
+	var start int
+	if quoted {
+		start = strings.LastIndex(head, `"`) + 1
+	} else {
+		start = strings.LastIndex(head, " ") + 1
+	}
+	partial := strings.ToLower(head[start:])
+	words := shellSplit(head[:start])
+	candidates := names
+	if len(words) == 0 {
+		candidates = shellCommands
+	} else if len(words) == 1 {
+		candidates = append(append([]string(nil), shellSubcommands[words[0]]...), names...)
+	}
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(strings.ToLower(c), partial) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+	completion := matches[0]
+	for _, m := range matches[1:] {
+		i := 0
+		for i < len(completion) && i < len(m) && strings.EqualFold(completion[i:i+1], m[i:i+1]) {
+			i++
+		}
+		completion = completion[:i]
+	}
+	if len(completion) < len(partial) {
+		return "", 0, false
+	}
+	if !quoted && strings.Contains(completion, " ") {
+		completion = `"` + completion
+		quoted = true
+	}
+	if len(matches) == 1 {
+		if quoted {
+			completion += `"`
+		}
+		completion += " "
+	}
+	newHead := head[:start] + completion
+	return newHead + line[pos:], len(newHead), true
+}
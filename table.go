@@ -1,15 +1,18 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
-	"github.com/gholt/brimtext"
+	"github.com/gholt/bdot/table"
+	"golang.org/x/term"
 )
 
-func table(args []string) {
+func tableCommand(args []string) {
 	if len(args) == 0 {
 		help("", 0)
 	}
@@ -18,12 +21,27 @@ func table(args []string) {
 		tableSearch(args[1:])
 	case "search-column":
 		tableSearchColumn(args[1:])
+	case "query":
+		tableQuery(args[1:])
+	case "join":
+		tableJoin(args[1:])
+	case "validate":
+		tableValidate(args[1:])
+	case "add":
+		tableAdd(args[1:])
+	case "set":
+		tableSet(args[1:])
+	case "delete":
+		tableDelete(args[1:])
 	default:
 		help(fmt.Sprintf("Unknown command table %q.", args[0]), 1)
 	}
 }
 
 func errnil(err error) {
+	if err != nil && inShell {
+		panic(shellError(err.Error()))
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -31,20 +49,49 @@ func errnil(err error) {
 }
 
 func tableSearch(args []string) {
+	var count bool
+	if len(args) > 0 && args[0] == "--count" {
+		count = true
+		args = args[1:]
+	}
 	if len(args) < 2 {
 		help("table search needs a <file> and <phrase>", 1)
 	}
 	header, data := tableRead(args[0])
-	phrase := strings.ToLower(strings.Join(args[1:], " "))
+	printTable(tableSearchReport(header, data, strings.Join(args[1:], " "), count))
+}
+
+// tableSearchReport returns the report for table search of the rows for the
+// phrase, or of how many rows matched in each column if count is true.
+func tableSearchReport(header []string, data [][]string, phrase string, count bool) [][]string {
+	phrase = strings.ToLower(phrase)
+	counts := make([]int, len(header))
+	rows := 0
 	report := [][]string{header, nil}
 	for _, row := range data {
-		for _, column := range row {
+		var highlighted []string
+		for i, column := range row {
 			if strings.Contains(strings.ToLower(column), phrase) {
-				report = append(report, row)
+				if highlighted == nil {
+					highlighted = append([]string(nil), row...)
+				}
+				highlighted[i] = tableHighlight(column, phrase)
+				counts[i]++
 			}
 		}
+		if highlighted != nil {
+			rows++
+			report = append(report, highlighted)
+		}
+	}
+	if count {
+		report = [][]string{{"Column", "Rows"}, nil}
+		for i, column := range header {
+			report = append(report, []string{column, strconv.Itoa(counts[i])})
+		}
+		report = append(report, nil, []string{"Any", strconv.Itoa(rows)})
 	}
-	fmt.Print(brimtext.Align(report, brimtext.NewSimpleAlignOptions()))
+	return report
 }
 
 func tableSearchColumn(args []string) {
@@ -52,63 +99,163 @@ func tableSearchColumn(args []string) {
 		help("table search-column needs a <file>, <column>, and <phrase>", 1)
 	}
 	header, data := tableRead(args[0])
-	columnSearch := args[1]
-	columnMatch := -1
-	for columnIndex, column := range header {
-		if strings.ToLower(column) == strings.ToLower(columnSearch) {
-			columnMatch = columnIndex
-			break
-		}
-	}
-	if columnMatch == -1 {
-		errnil(fmt.Errorf("Could not find column %q", columnSearch))
-	}
+	columnMatch := tableColumn(header, args[1])
 	phrase := strings.ToLower(strings.Join(args[2:], " "))
 	report := [][]string{header, nil}
 	for _, row := range data {
 		if strings.Contains(strings.ToLower(row[columnMatch]), phrase) {
-			report = append(report, row)
+			highlighted := append([]string(nil), row...)
+			highlighted[columnMatch] = tableHighlight(row[columnMatch], phrase)
+			report = append(report, highlighted)
 		}
 	}
-	fmt.Print(brimtext.Align(report, brimtext.NewSimpleAlignOptions()))
+	printTable(report)
 }
 
-func tableRead(filename string) (header []string, data [][]string) {
-	f, err := os.Open(filename)
-	errnil(err)
-	scanner := bufio.NewScanner(f)
-	lineNumber := 0
-	shouldBeNoMoreLines := false
-	for scanner.Scan() {
-		if shouldBeNoMoreLines {
-			errnil(fmt.Errorf("trailing lines after line number %d", lineNumber))
+// tableColumn returns the index of the named column, ignoring case, or exits
+// with an error if there is no such column.
+func tableColumn(header []string, name string) int {
+	for columnIndex, column := range header {
+		if strings.ToLower(column) == strings.ToLower(name) {
+			return columnIndex
 		}
-		lineNumber++
-		strs := strings.Split(scanner.Text(), "|")
-		if len(strs) == 1 {
-			if lineNumber != 1 && lineNumber != 3 {
-				shouldBeNoMoreLines = true
-			}
+	}
+	errnil(fmt.Errorf("Could not find column %q", name))
+	return -1
+}
+
+func tableJoin(args []string) {
+	var left bool
+	if len(args) > 0 && args[0] == "--left" {
+		left = true
+		args = args[1:]
+	}
+	if len(args) < 4 {
+		help("table join needs a <file a>, <column a>, <file b>, and <column b>", 1)
+	}
+	headerA, dataA := tableRead(args[0])
+	columnA := tableColumn(headerA, args[1])
+	headerB, dataB := tableRead(args[2])
+	columnB := tableColumn(headerB, args[3])
+	// The key column is only included once, from file a, and other columns
+	// of file b with the same name as one of file a's are named for file b.
+	header := append([]string(nil), headerA...)
+	for i, column := range headerB {
+		if i == columnB {
 			continue
 		}
-		if len(strs) < 3 {
-			errnil(fmt.Errorf("line number %d has too few columns", lineNumber))
+		for _, c := range headerA {
+			if strings.EqualFold(c, column) {
+				column = fmt.Sprintf("%s (%s)", column, filepath.Base(args[2]))
+				break
+			}
+		}
+		header = append(header, column)
+	}
+	byKey := map[string][][]string{}
+	for _, row := range dataB {
+		key := strings.ToLower(row[columnB])
+		byKey[key] = append(byKey[key], row)
+	}
+	var data [][]string
+	for _, rowA := range dataA {
+		rowsB := byKey[strings.ToLower(rowA[columnA])]
+		if len(rowsB) == 0 && left {
+			rowsB = [][]string{make([]string, len(headerB))}
 		}
-		if strs[0] != "" || strs[len(strs)-1] != "" {
-			errnil(fmt.Errorf("line number %d is malformed", lineNumber))
+		for _, rowB := range rowsB {
+			row := append([]string(nil), rowA...)
+			for i, column := range rowB {
+				if i != columnB {
+					row = append(row, column)
+				}
+			}
+			data = append(data, row)
 		}
-		strs = strs[1 : len(strs)-1]
-		if len(data) > 0 && len(strs) != len(data[0]) {
-			errnil(fmt.Errorf("line number %d has incorrect number of columns; had %d and expected %d", lineNumber, len(strs), len(data[0])))
+	}
+	if len(args) > 4 {
+		printTable(tableSearchReport(header, data, strings.Join(args[4:], " "), false))
+		return
+	}
+	printTable(append([][]string{header, nil}, data...))
+}
+
+// tableHighlight marks where the lower case phrase appears in the cell, in
+// bold red if stdout is a terminal or between asterisks otherwise. Other
+// output formats are left as they are.
+func tableHighlight(cell string, phrase string) string {
+	if outputFormat != "text" || phrase == "" {
+		return cell
+	}
+	start, end := "*", "*"
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		start, end = "\x1b[1;31m", "\x1b[0m"
+	}
+	cellL := strings.ToLower(cell)
+	if len(cellL) != len(cell) {
+		// Changing case changed the length, so the positions would be off.
+		return start + cell + end
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(cellL, phrase)
+		if i < 0 {
+			b.WriteString(cell)
+			return b.String()
+		}
+		b.WriteString(cell[:i] + start + cell[i:i+len(phrase)] + end)
+		cell = cell[i+len(phrase):]
+		cellL = cellL[i+len(phrase):]
+	}
+}
+
+func tableRead(filename string) (header []string, data [][]string) {
+	f, err := os.Open(filename)
+	errnil(err)
+	defer f.Close()
+	header, data, err = table.Read(f)
+	if err != nil {
+		errnil(fmt.Errorf("%s: %s", filename, err))
+	}
+	return header, data
+}
+
+func tableReadFrom(r io.Reader) (header []string, data [][]string) {
+	header, data, err := table.Read(r)
+	errnil(err)
+	return header, data
+}
+
+func tableValidate(args []string) {
+	if len(args) != 1 {
+		help("table validate needs a <file>", 1)
+	}
+	f, err := os.Open(args[0])
+	errnil(err)
+	errs, err := table.Validate(f)
+	f.Close()
+	errnil(err)
+	switch outputFormat {
+	case "json":
+		if errs == nil {
+			errs = []*table.Error{}
+		}
+		printJSON(errs)
+	case "csv":
+		rows := [][]string{{"Line", "Column", "Message"}}
+		for _, e := range errs {
+			rows = append(rows, []string{strconv.Itoa(e.Line), strconv.Itoa(e.Column), e.Message})
+		}
+		printCSV(rows)
+	default:
+		for _, e := range errs {
+			fmt.Printf("%s: %s\n", args[0], e)
 		}
-		for i, s := range strs {
-			strs[i] = strings.TrimSpace(s)
+		if len(errs) == 0 {
+			fmt.Printf("%s is a valid table.\n", args[0])
 		}
-		data = append(data, strs)
 	}
-	errnil(scanner.Err())
-	if len(data) < 2 {
-		errnil(fmt.Errorf("no data"))
+	if len(errs) > 0 {
+		errnil(fmt.Errorf("%s is not a valid table.", args[0]))
 	}
-	return data[0], data[1:]
 }
@@ -0,0 +1,199 @@
+// Package table reads the table file format bdot uses, an aligned text
+// table:
+//
+//	+--------+-------+
+//	| Item   | Price |
+//	+--------+-------+
+//	| Potato | 1,200 |
+//	+--------+-------+
+//
+// The first row is the header. Separator lines are made of + - = and : and
+// may appear between any rows; Markdown style separators such as
+// |---|:---:| are also allowed. Within a cell \| is a pipe and \\ is a
+// backslash, and cells are trimmed of surrounding spaces. Blank lines are
+// only allowed at the end.
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Error is a problem with a table file. Column is the byte position within
+// the line, starting at 1, or 0 if the problem is with the line as a whole.
+type Error struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Read returns the header and data rows of the table read from r, or the
+// first problem found as an *Error.
+func Read(r io.Reader) (header []string, data [][]string, err error) {
+	header, data, errs, err := parse(r, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(errs) > 0 {
+		return nil, nil, errs[0]
+	}
+	return header, data, nil
+}
+
+// Validate returns every problem found with the table read from r. The error
+// is only for failing to read r.
+func Validate(r io.Reader) ([]*Error, error) {
+	_, _, errs, err := parse(r, false)
+	return errs, err
+}
+
+// Escape returns the cell with pipes and backslashes escaped so Read will
+// read it back as it was.
+func Escape(cell string) string {
+	if !strings.ContainsAny(cell, `|\`) {
+		return cell
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(cell, `\`, `\\`), "|", `\|`)
+}
+
+func parse(r io.Reader, first bool) (header []string, data [][]string, errs []*Error, err error) {
+	fail := func(line int, column int, format string, args ...interface{}) bool {
+		errs = append(errs, &Error{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+		return first
+	}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	blank := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" {
+			if blank == 0 {
+				blank = lineNumber
+			}
+			continue
+		}
+		if blank > 0 {
+			if fail(blank, 0, "blank line within the table") {
+				return
+			}
+			blank = 0
+		}
+		if !strings.Contains(line, "|") {
+			if i := strings.IndexFunc(line, func(r rune) bool { return !strings.ContainsRune("+-=: ", r) }); i >= 0 {
+				if fail(lineNumber, i+1, "expected a row starting with | or a separator of + - = and :") {
+					return
+				}
+			}
+			continue
+		}
+		cells, column, msg := split(line)
+		if msg != "" {
+			if fail(lineNumber, column, "%s", msg) {
+				return
+			}
+			continue
+		}
+		if markdownSeparator(cells) {
+			continue
+		}
+		if header == nil {
+			header = cells
+			seen := map[string]struct{}{}
+			for _, c := range cells {
+				if _, ok := seen[strings.ToLower(c)]; ok {
+					if fail(lineNumber, 0, "column %q is in the header more than once", c) {
+						return
+					}
+				}
+				seen[strings.ToLower(c)] = struct{}{}
+			}
+			continue
+		}
+		if len(cells) != len(header) {
+			if fail(lineNumber, cellColumn(line, len(header)), "row has %d columns but the header has %d", len(cells), len(header)) {
+				return
+			}
+			continue
+		}
+		data = append(data, cells)
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+	if header == nil {
+		fail(lineNumber, 0, "no header row")
+	} else if len(data) == 0 && len(errs) == 0 {
+		fail(lineNumber, 0, "no data rows")
+	}
+	return
+}
+
+// split returns the trimmed, unescaped cells of a row; or the column and
+// message of a problem with it.
+func split(line string) ([]string, int, string) {
+	start := strings.IndexFunc(line, func(r rune) bool { return r != ' ' && r != '\t' })
+	if line[start] != '|' {
+		return nil, start + 1, "expected | at the start of the row"
+	}
+	var cells []string
+	var cell strings.Builder
+	closed := false
+	for i := start + 1; i < len(line); i++ {
+		closed = false
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line) && (line[i+1] == '|' || line[i+1] == '\\'):
+			cell.WriteByte(line[i+1])
+			i++
+		case c == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+			closed = true
+		default:
+			cell.WriteByte(c)
+		}
+	}
+	if !closed {
+		return nil, len(line), "expected | at the end of the row"
+	}
+	return cells, 0, ""
+}
+
+// cellColumn returns the column where cell n, starting at 0, of the line
+// begins, or the end of the line if it has fewer cells.
+func cellColumn(line string, n int) int {
+	pipes := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '|':
+			if pipes == n {
+				return i + 1
+			}
+			pipes++
+		}
+	}
+	return len(line)
+}
+
+// markdownSeparator returns true if every cell is dashes with optional colons
+// at either end, such as --- or :---:.
+func markdownSeparator(cells []string) bool {
+	for _, c := range cells {
+		d := strings.TrimSuffix(strings.TrimPrefix(c, ":"), ":")
+		if d == "" || strings.Trim(d, "-") != "" {
+			return false
+		}
+	}
+	return len(cells) > 0
+}
@@ -0,0 +1,101 @@
+package table
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	header, data, err := Read(strings.NewReader(`
++--------+-------------+
+| Item   | Note        |
++========+=============+
+| Potato | a \| b      |
+| Corn   | back\\slash |
++--------+-------------+
+`[1:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Item", "Note"}; !reflect.DeepEqual(header, want) {
+		t.Errorf("header was %q, not %q", header, want)
+	}
+	if want := [][]string{{"Potato", "a | b"}, {"Corn", `back\slash`}}; !reflect.DeepEqual(data, want) {
+		t.Errorf("data was %q, not %q", data, want)
+	}
+}
+
+func TestReadMarkdown(t *testing.T) {
+	header, data, err := Read(strings.NewReader(`
+| Item | Price |
+|:-----|------:|
+| Corn | 900   |
+`[1:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Item", "Price"}; !reflect.DeepEqual(header, want) {
+		t.Errorf("header was %q, not %q", header, want)
+	}
+	if want := [][]string{{"Corn", "900"}}; !reflect.DeepEqual(data, want) {
+		t.Errorf("data was %q, not %q", data, want)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	for _, cell := range []string{"plain", "a | b", `back\slash`, `\|`, `ends with \`} {
+		_, data, err := Read(strings.NewReader("| Cell |\n| " + Escape(cell) + " |\n"))
+		if err != nil {
+			t.Errorf("%q: %s", cell, err)
+		} else if data[0][0] != cell {
+			t.Errorf("%q read back as %q", cell, data[0][0])
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	errs, err := Validate(strings.NewReader(`
+| Item | Price |
+| Corn | 900   |
+| Corn | 900   | extra |
+  Beer | 2,000 |
+| Milk | 100
++--- oops
+
+| Salt | 5     |
+`[1:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Error{
+		{Line: 3, Column: 16, Message: "row has 3 columns but the header has 2"},
+		{Line: 4, Column: 3, Message: "expected | at the start of the row"},
+		{Line: 5, Column: 12, Message: "expected | at the end of the row"},
+		{Line: 6, Column: 6, Message: "expected a row starting with | or a separator of + - = and :"},
+		{Line: 7, Message: "blank line within the table"},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		var got []string
+		for _, e := range errs {
+			got = append(got, e.Error())
+		}
+		t.Errorf("got:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestReadFirstError(t *testing.T) {
+	for text, want := range map[string]string{
+		"":                             "line 0: no header row",
+		"| Item |\n":                   "line 1: no data rows",
+		"| Item | Item |\n| a | b |\n": `line 1: column "Item" is in the header more than once`,
+		"| Item |\n| a | b |\n| c |\n": "line 2, column 5: row has 2 columns but the header has 1",
+	} {
+		_, _, err := Read(strings.NewReader(text))
+		if err == nil {
+			t.Errorf("%q read without error", text)
+		} else if err.Error() != want {
+			t.Errorf("%q: %s, not %s", text, err, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+func tableAdd(args []string) {
+	if len(args) < 2 {
+		help("table add needs a <file> and <column=value>...", 1)
+	}
+	header, data := tableRead(args[0])
+	row := make([]string, len(header))
+	for column, value := range tableAssignments(header, args[1:]) {
+		row[column] = value
+	}
+	tableWrite(args[0], header, append(data, row))
+	if outputFormat != "text" {
+		printTable([][]string{header, nil, row})
+		return
+	}
+	fmt.Println("Added 1 row.")
+}
+
+func tableSet(args []string) {
+	if len(args) < 4 {
+		help("table set needs a <file>, <match column>, <match>, and <column=value>...", 1)
+	}
+	header, data := tableRead(args[0])
+	matchColumn := tableColumn(header, args[1])
+	assignments := tableAssignments(header, args[3:])
+	changed := [][]string{header, nil}
+	for _, row := range data {
+		if strings.EqualFold(row[matchColumn], args[2]) {
+			for column, value := range assignments {
+				row[column] = value
+			}
+			changed = append(changed, row)
+		}
+	}
+	if len(changed) == 2 {
+		errnil(fmt.Errorf("No rows have %s %q; nothing was changed.", header[matchColumn], args[2]))
+	}
+	tableWrite(args[0], header, data)
+	if outputFormat != "text" {
+		printTable(changed)
+		return
+	}
+	fmt.Printf("Updated %d rows.\n", len(changed)-2)
+}
+
+func tableDelete(args []string) {
+	if len(args) < 3 {
+		help("table delete needs a <file>, <column>, and <phrase>", 1)
+	}
+	header, data := tableRead(args[0])
+	column := tableColumn(header, args[1])
+	phrase := strings.ToLower(strings.Join(args[2:], " "))
+	var kept [][]string
+	deleted := [][]string{header, nil}
+	for _, row := range data {
+		if strings.Contains(strings.ToLower(row[column]), phrase) {
+			deleted = append(deleted, row)
+		} else {
+			kept = append(kept, row)
+		}
+	}
+	if len(kept) == len(data) {
+		errnil(fmt.Errorf("No rows have %q in %s; nothing was deleted.", phrase, header[column]))
+	}
+	if len(kept) == 0 {
+		errnil(fmt.Errorf("Every row has %q in %s, and a table needs at least one row; nothing was deleted.", phrase, header[column]))
+	}
+	tableWrite(args[0], header, kept)
+	if outputFormat != "text" {
+		printTable(deleted)
+		return
+	}
+	fmt.Printf("Deleted %d rows.\n", len(deleted)-2)
+}
+
+// tableAssignments returns the values to set by column index from arguments
+// of the form <column>=<value>, or exits with an error.
+func tableAssignments(header []string, args []string) map[int]string {
+	assignments := map[int]string{}
+	for _, arg := range args {
+		t := strings.SplitN(arg, "=", 2)
+		if len(t) != 2 {
+			help(fmt.Sprintf("Expected <column>=<value>, not %q.", arg), 1)
+		}
+		assignments[tableColumn(header, strings.TrimSpace(t[0]))] = strings.TrimSpace(t[1])
+	}
+	return assignments
+}
+
+// tableWrite rewrites the table file, aligned, after copying the previous
+// version to <filename>.bak.
+func tableWrite(filename string, header []string, data [][]string) {
+	previous, err := os.ReadFile(filename)
+	errnil(err)
+	errnil(writeFileAtomic(filename+".bak", previous))
+	errnil(writeFileAtomic(filename, []byte(alignTable(append([][]string{header, nil}, data...)))))
+}
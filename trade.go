@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gholt/bdot/bdo"
+)
+
+func tradeCommand(g *bdo.Graph, args []string) {
+	var cmd string
+	if len(args) > 0 {
+		cmd = args[0]
+		args = args[1:]
+	}
+	switch cmd {
+	case "route":
+		if len(args) != 2 {
+			help("trade route needs a <from town> and <to town>", 1)
+		}
+		tradeRoute(g, findNode(g, args[0]), findNode(g, args[1]))
+	default:
+		help(fmt.Sprintf("Unknown trade command %q.", cmd), 1)
+	}
+}
+
+func tradeRoute(g *bdo.Graph, from string, to string) {
+	tr, err := g.TradeRoute(from, to)
+	if err != nil {
+		help(err.Error(), 1)
+	}
+	switch outputFormat {
+	case "json":
+		printJSON(tr)
+		return
+	case "csv":
+		distance, bonus := "", ""
+		if tr.Distance != nil {
+			distance = strconv.FormatFloat(*tr.Distance, 'f', 0, 64)
+		}
+		if tr.Bonus != nil {
+			bonus = strconv.FormatFloat(*tr.Bonus, 'f', 1, 64)
+		}
+		printCSV([][]string{
+			{"From", "To", "Hops", "Route Hops", "Cost", "Distance", "Bonus", "Trade Nodes", "Unowned"},
+			{tr.From, tr.To, strconv.Itoa(tr.Hops), strconv.Itoa(len(tr.Route) - 1), strconv.Itoa(tr.Cost), distance, bonus, strings.Join(tr.TradeNodes, ", "), strings.Join(tr.Unowned, ", ")},
+		})
+		return
+	}
+	fmt.Printf("%s to %s is %d connections at the fewest.\n", tr.From, tr.To, tr.Hops)
+	if tr.Distance != nil {
+		if tr.Bonus != nil {
+			fmt.Printf("The straight-line distance is %.0f, for a distance bonus of %.1f%%.\n", *tr.Distance, *tr.Bonus)
+		} else {
+			fmt.Printf("The straight-line distance is %.0f; the distance bonus is not known, as the node data has no tradeBonus.\n", *tr.Distance)
+		}
+	} else {
+		fmt.Printf("The distance bonus is not known, as the position of %s or %s is not in the node data.\n", tr.From, tr.To)
+	}
+	fmt.Printf("The cheapest route to connect, %d connections for %d contribution points:\n", len(tr.Route)-1, tr.Cost)
+	for _, name := range tr.Route {
+		fmt.Printf("    %s\n", g.Node(name))
+	}
+	if len(tr.TradeNodes) == 0 {
+		fmt.Println("No trade nodes are on the route.")
+		return
+	}
+	var trade []string
+	for _, name := range tr.TradeNodes {
+		if g.Node(name).Owned {
+			trade = append(trade, name+" (owned)")
+		} else {
+			trade = append(trade, name)
+		}
+	}
+	fmt.Printf("Trade nodes on the route: %s.\n", strings.Join(trade, ", "))
+	if len(tr.Unowned) > 0 {
+		fmt.Printf("Own %s for the trade to count as connected.\n", strings.Join(tr.Unowned, ", "))
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gholt/bdot/bdo"
+)
+
+// readWorkers reads the "workers" file, which lists how many workers each
+// town has, one town per line as "<town> -- <count>".
+func readWorkers(g *bdo.Graph) map[string]int {
+	f, err := os.Open("workers")
+	if os.IsNotExist(err) {
+		help(`There is no "workers" file listing how many workers each town has.`, 1)
+	}
+	errnil(err)
+	defer f.Close()
+	workers := map[string]int{}
+	scanner := bufio.NewScanner(f)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		t := strings.SplitN(line, " -- ", 2)
+		if len(t) != 2 {
+			errnil(fmt.Errorf("On line %d of workers, %q: expected <town> -- <count>", lineNumber, line))
+		}
+		town, err := g.Resolve(t[0])
+		if err != nil {
+			errnil(fmt.Errorf("On line %d of workers, %q: %s", lineNumber, line, err))
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(t[1]))
+		if err != nil || count < 0 {
+			errnil(fmt.Errorf("On line %d of workers, %q: invalid count %q", lineNumber, line, t[1]))
+		}
+		workers[town.Name] += count
+	}
+	errnil(scanner.Err())
+	return workers
+}
+
+func nodesAssign(g *bdo.Graph, args []string) {
+	assignments := g.Assign(readWorkers(g), args)
+	assigned := map[string]*bdo.Assignment{}
+	for _, a := range assignments {
+		assigned[a.Node] = a
+	}
+	type change struct {
+		Node string `json:"node"`
+		From string `json:"from"`
+		To   string `json:"to"`
+		Hops int    `json:"hops,omitempty"`
+	}
+	changes := []*change{}
+	unchanged := 0
+	for _, n := range g.Nodes() {
+		if !n.Owned || len(n.Produces) == 0 {
+			continue
+		}
+		c := &change{Node: n.Name, From: n.AssignedWorker}
+		if a := assigned[n.Name]; a != nil {
+			c.To = a.Town
+			c.Hops = a.Hops
+		}
+		if c.From == c.To {
+			unchanged++
+			continue
+		}
+		changes = append(changes, c)
+	}
+	switch outputFormat {
+	case "json":
+		printJSON(struct {
+			Assignments []*bdo.Assignment `json:"assignments"`
+			Changes     []*change         `json:"changes"`
+		}{assignments, changes})
+		return
+	case "csv":
+		rows := [][]string{{"Node", "From", "To", "Hops"}}
+		for _, c := range changes {
+			rows = append(rows, []string{c.Node, c.From, c.To, strconv.Itoa(c.Hops)})
+		}
+		printCSV(rows)
+		return
+	}
+	hops := 0
+	for _, a := range assignments {
+		hops += a.Hops
+	}
+	fmt.Printf("%d production nodes can be worked for %d total hops of travel.\n", len(assignments), hops)
+	if len(changes) == 0 {
+		fmt.Println("No changes are needed.")
+		return
+	}
+	fmt.Printf("\n%d changes from the current assignments, %d unchanged:\n", len(changes), unchanged)
+	for _, c := range changes {
+		from := c.From
+		if from == "" {
+			from = "unassigned"
+		}
+		if c.To == "" {
+			fmt.Printf("    %s: %s -> unassigned\n", c.Node, from)
+		} else {
+			fmt.Printf("    %s: %s -> %s (%d hops)\n", c.Node, from, c.To, c.Hops)
+		}
+	}
+}